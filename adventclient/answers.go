@@ -0,0 +1,125 @@
+package adventclient
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strconv"
+)
+
+// Known is what we've learned about one part's answer from submitting it.
+type Known struct {
+    Correct string `json:"correct,omitempty"`
+    Wrong []string `json:"wrong,omitempty"`
+    // the smallest answer known to be too high and the largest known to be too low
+    High *int64 `json:"too_high,omitempty"`
+    Low *int64 `json:"too_low,omitempty"`
+}
+
+// Answers is a store of submitted answers, kept as JSON on disk.
+type Answers struct {
+    path string
+    Days map[int]map[int]*Known `json:"days"`
+}
+
+// AnswersPath is where the client keeps its answer store.
+func (c *Client) AnswersPath() string {
+    return filepath.Join(c.CacheDir, "answers.json")
+}
+
+// LoadAnswers reads the store at path; a missing file is an empty store.
+func LoadAnswers(path string) (*Answers, error) {
+    answers := &Answers{path: path, Days: make(map[int]map[int]*Known)}
+    data, err := os.ReadFile(path)
+    if errors.Is(err, fs.ErrNotExist) {
+        return answers, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    if err := json.Unmarshal(data, answers); err != nil {
+        return nil, fmt.Errorf("parsing %s: %w", path, err)
+    }
+    if answers.Days == nil {
+        answers.Days = make(map[int]map[int]*Known)
+    }
+    return answers, nil
+}
+
+// Save writes the store back to where it was loaded from.
+func (a *Answers) Save() error {
+    data, err := json.MarshalIndent(a, "", "  ")
+    if err != nil {
+        return err
+    }
+    return writeFileAtomic(a.path, append(data, '\n'))
+}
+
+// Get returns what's known about a part, or nil.
+func (a *Answers) Get(day, part int) *Known {
+    return a.Days[day][part]
+}
+
+func (a *Answers) known(day, part int) *Known {
+    if a.Days[day] == nil {
+        a.Days[day] = make(map[int]*Known)
+    }
+    if a.Days[day][part] == nil {
+        a.Days[day][part] = &Known{}
+    }
+    return a.Days[day][part]
+}
+
+// Check returns an error if submitting answer would be pointless: the part is
+// already solved, or the answer is known (or bounded) to be wrong.
+func (a *Answers) Check(day, part int, answer string) error {
+    known := a.Get(day, part)
+    if known == nil {
+        return nil
+    }
+    if known.Correct != "" {
+        if known.Correct == answer {
+            return fmt.Errorf("%s is already known to be correct", answer)
+        }
+        return fmt.Errorf("already solved, the answer was %s", known.Correct)
+    }
+    for _, wrong := range known.Wrong {
+        if wrong == answer {
+            return fmt.Errorf("%s was already submitted and is wrong", answer)
+        }
+    }
+    if value, err := strconv.ParseInt(answer, 10, 64); err == nil {
+        if known.High != nil && value >= *known.High {
+            return fmt.Errorf("%s is too high, %d already was", answer, *known.High)
+        }
+        if known.Low != nil && value <= *known.Low {
+            return fmt.Errorf("%s is too low, %d already was", answer, *known.Low)
+        }
+    }
+    return nil
+}
+
+// Record notes the site's verdict on an answer. Verdicts that say nothing
+// about the answer itself (too soon, already solved) are ignored.
+func (a *Answers) Record(day, part int, answer string, verdict Verdict) {
+    switch verdict {
+    case Correct:
+        a.known(day, part).Correct = answer
+    case Incorrect, TooHigh, TooLow:
+        known := a.known(day, part)
+        known.Wrong = append(known.Wrong, answer)
+        value, err := strconv.ParseInt(answer, 10, 64)
+        if err != nil {
+            return
+        }
+        if verdict == TooHigh && (known.High == nil || value < *known.High) {
+            known.High = &value
+        }
+        if verdict == TooLow && (known.Low == nil || value > *known.Low) {
+            known.Low = &value
+        }
+    }
+}
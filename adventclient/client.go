@@ -0,0 +1,119 @@
+package adventclient
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+const userAgent = "github.com/Resisty/advent2021 adventclient"
+
+// Client talks to the Advent of Code site (or a stand-in at BaseURL) and
+// caches what it downloads under CacheDir.
+type Client struct {
+    BaseURL string
+    Session string
+    CacheDir string
+    HTTP *http.Client
+    Throttle *Throttle
+}
+
+// New builds a client from config, throttled per its min_interval.
+func New(cfg *Config) (*Client, error) {
+    interval, err := cfg.Interval()
+    if err != nil {
+        return nil, err
+    }
+    return &Client{
+        BaseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+        Session: cfg.Session,
+        CacheDir: cfg.CacheDir,
+        HTTP: http.DefaultClient,
+        Throttle: NewThrottle(interval, filepath.Join(cfg.CacheDir, "last-request")),
+    }, nil
+}
+
+// InputPath is where a day's input is cached. The layout matches
+// ADVENT_INPUT_DIR so the cache can be searched for inputs directly.
+func (c *Client) InputPath(day int) string {
+    return filepath.Join(c.CacheDir, fmt.Sprintf("day%02d", day), "input.txt")
+}
+
+// FetchInput makes sure the day's input is cached, downloading it only if it
+// isn't already, and returns its path and whether it was downloaded.
+func (c *Client) FetchInput(day int) (string, bool, error) {
+    path := c.InputPath(day)
+    if _, err := os.Stat(path); err == nil {
+        return path, false, nil
+    } else if ! errors.Is(err, fs.ErrNotExist) {
+        return "", false, err
+    }
+    body, err := c.get(fmt.Sprintf("/%d/day/%d/input", Year, day))
+    if err != nil {
+        return "", false, err
+    }
+    if err := writeFileAtomic(path, body); err != nil {
+        return "", false, err
+    }
+    return path, true, nil
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+    if c.Session == "" {
+        return nil, fmt.Errorf("no session cookie configured (set %s or \"session\" in the config file)", SessionEnv)
+    }
+    if c.Throttle != nil {
+        if err := c.Throttle.Wait(); err != nil {
+            return nil, err
+        }
+    }
+    req.Header.Set("User-Agent", userAgent)
+    req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+    resp, err := c.HTTP.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, err
+    }
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL, resp.Status, strings.TrimSpace(string(body)))
+    }
+    return body, nil
+}
+
+func (c *Client) get(path string) ([]byte, error) {
+    req, err := http.NewRequest(http.MethodGet, c.BaseURL + path, nil)
+    if err != nil {
+        return nil, err
+    }
+    return c.do(req)
+}
+
+// writeFileAtomic writes via a temporary file so an interrupted download
+// never leaves a truncated input in the cache.
+func writeFileAtomic(path string, data []byte) error {
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        return err
+    }
+    tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+    if _, err := tmp.Write(data); err != nil {
+        tmp.Close()
+        return err
+    }
+    if err := tmp.Close(); err != nil {
+        return err
+    }
+    return os.Rename(tmp.Name(), path)
+}
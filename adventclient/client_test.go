@@ -0,0 +1,136 @@
+package adventclient
+
+import (
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+    "advent2021/adventclient/fakeaoc"
+)
+
+// fakeClock stands in for time.Now and time.Sleep, sleeping by moving the
+// clock on and remembering how long for.
+type fakeClock struct {
+    now time.Time
+    slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+    return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+    c.slept = append(c.slept, d)
+    c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) throttle(interval time.Duration, path string) *Throttle {
+    return &Throttle{Interval: interval, Path: path, Now: c.Now, Sleep: c.Sleep}
+}
+
+func newFake(t *testing.T) (*fakeaoc.Server, *httptest.Server) {
+    fake := fakeaoc.New("secret")
+    fake.Inputs[1] = "199\n200\n208\n"
+    fake.Answers[fakeaoc.Part{Day: 1, Part: 1}] = "7"
+    srv := httptest.NewServer(fake)
+    t.Cleanup(srv.Close)
+    return fake, srv
+}
+
+func newTestClient(srv *httptest.Server, session string, t *testing.T) *Client {
+    return &Client{BaseURL: srv.URL, Session: session, CacheDir: t.TempDir(), HTTP: srv.Client()}
+}
+
+func TestFetchInputCaches(t *testing.T) {
+    fake, srv := newFake(t)
+    c := newTestClient(srv, "secret", t)
+    path, fetched, err := c.FetchInput(1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if ! fetched || path != filepath.Join(c.CacheDir, "day01", "input.txt") {
+        t.Errorf("first fetch: got %s, fetched %t", path, fetched)
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(data) != fake.Inputs[1] {
+        t.Errorf("cached %q, want %q", data, fake.Inputs[1])
+    }
+    for i := 0; i < 3; i++ {
+        if _, fetched, err := c.FetchInput(1); err != nil || fetched {
+            t.Errorf("fetch %d of a cached day: fetched %t, %v", i + 2, fetched, err)
+        }
+    }
+    if requests := fake.Requests(); requests != 1 {
+        t.Errorf("server saw %d requests, want 1", requests)
+    }
+}
+
+func TestFetchInputWrongSession(t *testing.T) {
+    _, srv := newFake(t)
+    c := newTestClient(srv, "not-it", t)
+    if _, _, err := c.FetchInput(1); err == nil {
+        t.Fatal("fetched with the wrong session")
+    }
+    if _, err := os.Stat(c.InputPath(1)); err == nil {
+        t.Error("a failed fetch left something in the cache")
+    }
+    c.Session = ""
+    if _, _, err := c.FetchInput(1); err == nil {
+        t.Error("fetched with no session")
+    }
+}
+
+func TestFetchInputThrottled(t *testing.T) {
+    fake, srv := newFake(t)
+    fake.Inputs[2] = "forward 5\n"
+    clock := &fakeClock{now: time.Unix(1000, 0)}
+    c := newTestClient(srv, "secret", t)
+    c.Throttle = clock.throttle(time.Minute, "")
+    for day := 1; day <= 2; day++ {
+        if _, _, err := c.FetchInput(day); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if len(clock.slept) != 1 || clock.slept[0] != time.Minute {
+        t.Errorf("slept %v between two downloads, want [1m0s]", clock.slept)
+    }
+}
+
+func TestThrottleWait(t *testing.T) {
+    clock := &fakeClock{now: time.Unix(1000, 0)}
+    path := filepath.Join(t.TempDir(), "last-request")
+    throttle := clock.throttle(5 * time.Second, path)
+    if err := throttle.Wait(); err != nil {
+        t.Fatal(err)
+    }
+    if len(clock.slept) != 0 {
+        t.Fatalf("first request slept %v", clock.slept)
+    }
+    clock.now = clock.now.Add(2 * time.Second)
+    if err := throttle.Wait(); err != nil {
+        t.Fatal(err)
+    }
+    if len(clock.slept) != 1 || clock.slept[0] != 3 * time.Second {
+        t.Fatalf("slept %v, want the remaining [3s]", clock.slept)
+    }
+    // another run sharing the file waits too
+    clock.now = clock.now.Add(time.Second)
+    if err := clock.throttle(5 * time.Second, path).Wait(); err != nil {
+        t.Fatal(err)
+    }
+    if len(clock.slept) != 2 || clock.slept[1] != 4 * time.Second {
+        t.Fatalf("slept %v, want [3s 4s]", clock.slept)
+    }
+    // and once the interval has passed nobody waits
+    clock.now = clock.now.Add(time.Minute)
+    if err := throttle.Wait(); err != nil {
+        t.Fatal(err)
+    }
+    if len(clock.slept) != 2 {
+        t.Errorf("slept %v after the interval had passed", clock.slept)
+    }
+}
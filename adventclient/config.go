@@ -0,0 +1,100 @@
+package adventclient
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+const (
+    DefaultBaseURL = "https://adventofcode.com"
+    DefaultInterval = 15 * time.Second
+    Year = 2021
+)
+
+// Environment variables that override the config file.
+const (
+    SessionEnv = "ADVENT_SESSION"
+    BaseURLEnv = "ADVENT_BASE_URL"
+    CacheDirEnv = "ADVENT_CACHE_DIR"
+)
+
+// Config is read from $XDG_CONFIG_HOME/advent2021/config.json (or the
+// platform equivalent), e.g.
+//
+//   {"session": "53616c...", "min_interval": "30s"}
+type Config struct {
+    Session string `json:"session"`
+    BaseURL string `json:"base_url"`
+    CacheDir string `json:"cache_dir"`
+    MinInterval string `json:"min_interval"`
+}
+
+// DefaultConfigPath is where LoadConfig looks when given no path.
+func DefaultConfigPath() (string, error) {
+    dir, err := os.UserConfigDir()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(dir, "advent2021", "config.json"), nil
+}
+
+// LoadConfig reads the config file at path (or the default path when empty),
+// tolerating its absence, then applies environment overrides and defaults.
+func LoadConfig(path string) (*Config, error) {
+    explicit := path != ""
+    if ! explicit {
+        var err error
+        if path, err = DefaultConfigPath(); err != nil {
+            return nil, err
+        }
+    }
+    cfg := &Config{}
+    data, err := os.ReadFile(path)
+    switch {
+    case err == nil:
+        if err := json.Unmarshal(data, cfg); err != nil {
+            return nil, fmt.Errorf("parsing %s: %w", path, err)
+        }
+    case errors.Is(err, fs.ErrNotExist) && ! explicit:
+        // no config file is fine, everything can come from the environment
+    default:
+        return nil, err
+    }
+    if session := os.Getenv(SessionEnv); session != "" {
+        cfg.Session = session
+    }
+    if baseURL := os.Getenv(BaseURLEnv); baseURL != "" {
+        cfg.BaseURL = baseURL
+    }
+    if cacheDir := os.Getenv(CacheDirEnv); cacheDir != "" {
+        cfg.CacheDir = cacheDir
+    }
+    if cfg.BaseURL == "" {
+        cfg.BaseURL = DefaultBaseURL
+    }
+    if cfg.CacheDir == "" {
+        dir, err := os.UserCacheDir()
+        if err != nil {
+            return nil, err
+        }
+        cfg.CacheDir = filepath.Join(dir, "advent2021")
+    }
+    return cfg, nil
+}
+
+// Interval is the minimum time between requests to the server.
+func (c *Config) Interval() (time.Duration, error) {
+    if c.MinInterval == "" {
+        return DefaultInterval, nil
+    }
+    interval, err := time.ParseDuration(c.MinInterval)
+    if err != nil {
+        return 0, fmt.Errorf("min_interval: %w", err)
+    }
+    return interval, nil
+}
@@ -0,0 +1,126 @@
+// Package fakeaoc is a stand-in for the Advent of Code site, so fetching and
+// submitting can be exercised offline (wrap it in httptest.NewServer, or run
+// cmd/fakeaoc).
+package fakeaoc
+
+import (
+    "fmt"
+    "net/http"
+    "regexp"
+    "strconv"
+    "sync"
+    "time"
+)
+
+// Part identifies one half of a day's puzzle.
+type Part struct {
+    Day, Part int
+}
+
+// Server serves Inputs and judges submissions against Answers for requests
+// carrying the Session cookie, refusing answers sent within Cooldown of the
+// last one. It counts the requests it has seen.
+type Server struct {
+    Session string
+    Inputs map[int]string
+    Answers map[Part]string
+    Cooldown time.Duration
+
+    mu sync.Mutex
+    requests int
+    lastAnswer time.Time
+    solved map[Part]bool
+}
+
+func New(session string) *Server {
+    return &Server{
+        Session: session,
+        Inputs: make(map[int]string),
+        Answers: make(map[Part]string),
+        solved: make(map[Part]bool),
+    }
+}
+
+// Requests is the number of requests served so far.
+func (s *Server) Requests() int {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.requests
+}
+
+var (
+    inputPath = regexp.MustCompile(`^/(\d+)/day/(\d+)/input$`)
+    answerPath = regexp.MustCompile(`^/(\d+)/day/(\d+)/answer$`)
+)
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.requests += 1
+    if cookie, err := r.Cookie("session"); err != nil || cookie.Value != s.Session {
+        http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+        return
+    }
+    if match := inputPath.FindStringSubmatch(r.URL.Path); match != nil && r.Method == http.MethodGet {
+        day, _ := strconv.Atoi(match[2])
+        s.serveInput(w, r, day)
+        return
+    }
+    if match := answerPath.FindStringSubmatch(r.URL.Path); match != nil && r.Method == http.MethodPost {
+        day, _ := strconv.Atoi(match[2])
+        s.serveAnswer(w, r, day)
+        return
+    }
+    http.NotFound(w, r)
+}
+
+func (s *Server) serveInput(w http.ResponseWriter, r *http.Request, day int) {
+    input, ok := s.Inputs[day]
+    if ! ok {
+        http.NotFound(w, r)
+        return
+    }
+    fmt.Fprint(w, input)
+}
+
+func (s *Server) serveAnswer(w http.ResponseWriter, r *http.Request, day int) {
+    level, err := strconv.Atoi(r.PostFormValue("level"))
+    if err != nil {
+        http.Error(w, "bad level", http.StatusBadRequest)
+        return
+    }
+    part := Part{day, level}
+    correct, ok := s.Answers[part]
+    if ! ok || s.solved[part] {
+        article(w, "You don't seem to be solving the right level.  Did you already complete it? [Return to Day %d]", day)
+        return
+    }
+    now := time.Now()
+    if left := s.lastAnswer.Add(s.Cooldown).Sub(now); left > 0 {
+        left = left.Round(time.Second)
+        article(w, "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %dm %ds left to wait. [Return to Day %d]", int(left.Minutes()), int(left.Seconds()) % 60, day)
+        return
+    }
+    s.lastAnswer = now
+    answer := r.PostFormValue("answer")
+    if answer == correct {
+        s.solved[part] = true
+        article(w, "That's the right answer!  You are one gold star closer to saving Christmas. [Continue to Part Two]")
+        return
+    }
+    hint := ""
+    given, givenErr := strconv.ParseInt(answer, 10, 64)
+    want, wantErr := strconv.ParseInt(correct, 10, 64)
+    if givenErr == nil && wantErr == nil {
+        if given > want {
+            hint = "; your answer is too high"
+        } else {
+            hint = "; your answer is too low"
+        }
+    }
+    article(w, "That's not the right answer%s.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. [Return to Day %d]", hint, day)
+}
+
+func article(w http.ResponseWriter, format string, v ...interface{}) {
+    fmt.Fprintf(w, "<html><body><main>\n<article><p>%s</p></article>\n</main></body></html>", fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,111 @@
+package adventclient
+
+import (
+    "fmt"
+    "html"
+    "net/http"
+    "net/url"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+type Verdict int
+
+const (
+    Unknown Verdict = iota
+    Correct
+    Incorrect
+    TooHigh
+    TooLow
+    TooSoon // answered too recently; try again after Wait
+    AlreadySolved // not solving this level, it's probably done already
+)
+
+func (v Verdict) String() string {
+    switch v {
+    case Correct:
+        return "correct"
+    case Incorrect:
+        return "incorrect"
+    case TooHigh:
+        return "too high"
+    case TooLow:
+        return "too low"
+    case TooSoon:
+        return "too soon"
+    case AlreadySolved:
+        return "already solved"
+    default:
+        return "unknown"
+    }
+}
+
+// Response is the site's reaction to a submitted answer.
+type Response struct {
+    Verdict Verdict
+    Wait time.Duration // how long before another answer is accepted, when the site says
+    Message string
+}
+
+// Submit posts an answer for one part of a day.
+func (c *Client) Submit(day, part int, answer string) (*Response, error) {
+    form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+    endpoint := fmt.Sprintf("%s/%d/day/%d/answer", c.BaseURL, Year, day)
+    req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+    if err != nil {
+        return nil, err
+    }
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    body, err := c.do(req)
+    if err != nil {
+        return nil, err
+    }
+    return ParseResponse(string(body)), nil
+}
+
+var (
+    articleReg = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+    tagReg = regexp.MustCompile(`<[^>]*>`)
+    spaceReg = regexp.MustCompile(`\s+`)
+    leftToWaitReg = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+    pleaseWaitReg = regexp.MustCompile(`[Pp]lease wait (one|\d+) minutes?`)
+)
+
+// ParseResponse reads the verdict out of the page returned for an answer.
+func ParseResponse(body string) *Response {
+    text := body
+    if match := articleReg.FindStringSubmatch(body); match != nil {
+        text = match[1]
+    }
+    text = html.UnescapeString(tagReg.ReplaceAllString(text, ""))
+    text = strings.TrimSpace(spaceReg.ReplaceAllString(text, " "))
+    resp := &Response{Message: text}
+    switch {
+    case strings.Contains(text, "That's the right answer"):
+        resp.Verdict = Correct
+    case strings.Contains(text, "your answer is too high"):
+        resp.Verdict = TooHigh
+    case strings.Contains(text, "your answer is too low"):
+        resp.Verdict = TooLow
+    case strings.Contains(text, "That's not the right answer"):
+        resp.Verdict = Incorrect
+    case strings.Contains(text, "You gave an answer too recently"):
+        resp.Verdict = TooSoon
+    case strings.Contains(text, "You don't seem to be solving the right level"):
+        resp.Verdict = AlreadySolved
+    }
+    if match := leftToWaitReg.FindStringSubmatch(text); match != nil {
+        minutes, _ := strconv.Atoi(match[1])
+        seconds, _ := strconv.Atoi(match[2])
+        resp.Wait = time.Duration(minutes) * time.Minute + time.Duration(seconds) * time.Second
+    } else if match := pleaseWaitReg.FindStringSubmatch(text); match != nil {
+        minutes := 1
+        if match[1] != "one" {
+            minutes, _ = strconv.Atoi(match[1])
+        }
+        resp.Wait = time.Duration(minutes) * time.Minute
+    }
+    return resp
+}
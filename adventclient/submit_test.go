@@ -0,0 +1,113 @@
+package adventclient
+
+import (
+    "path/filepath"
+    "testing"
+    "time"
+    "advent2021/adventclient/fakeaoc"
+)
+
+func TestParseResponse(t *testing.T) {
+    page := func(text string) string {
+        return "<html><body><main>\n<article><p>" + text + "</p></article>\n</main></body></html>"
+    }
+    for _, test := range []struct {
+        name string
+        body string
+        verdict Verdict
+        wait time.Duration
+    }{
+        {"right", page("That's the right answer!  You are one gold star closer to saving Christmas. <a href=\"/2021/day/1#part2\">[Continue to Part Two]</a>"), Correct, 0},
+        {"wrong", page("That's not the right answer.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again."), Incorrect, time.Minute},
+        {"too high", page("That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again."), TooHigh, time.Minute},
+        {"too low", page("That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again."), TooLow, 5 * time.Minute},
+        {"too soon", page("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 30s left to wait."), TooSoon, 90 * time.Second},
+        {"too soon, seconds", page("You gave an answer too recently.  You have 45s left to wait."), TooSoon, 45 * time.Second},
+        {"already solved", page("You don't seem to be solving the right level.  Did you already complete it?"), AlreadySolved, 0},
+        {"escaped and outside an article", "That&#39;s the right answer!", Correct, 0},
+        {"nonsense", page("Something else entirely."), Unknown, 0},
+    } {
+        resp := ParseResponse(test.body)
+        if resp.Verdict != test.verdict || resp.Wait != test.wait {
+            t.Errorf("%s: got %v, wait %v; want %v, wait %v (%q)", test.name, resp.Verdict, resp.Wait, test.verdict, test.wait, resp.Message)
+        }
+    }
+}
+
+func TestCheck(t *testing.T) {
+    answers, err := LoadAnswers(filepath.Join(t.TempDir(), "answers.json"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    answers.Record(1, 1, "100", Incorrect)
+    answers.Record(1, 1, "500", TooHigh)
+    answers.Record(1, 1, "900", TooHigh) // a looser bound doesn't replace a tighter one
+    answers.Record(1, 1, "200", TooLow)
+    answers.Record(1, 1, "222", TooSoon) // says nothing about the answer
+    answers.Record(2, 1, "42", Correct)
+    if err := answers.Save(); err != nil {
+        t.Fatal(err)
+    }
+    // everything should survive a trip through the file
+    answers, err = LoadAnswers(answers.path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, test := range []struct {
+        day, part int
+        answer string
+        refused bool
+    }{
+        {1, 1, "100", true},  // known wrong
+        {1, 1, "500", true},  // too high
+        {1, 1, "600", true},  // higher than too high
+        {1, 1, "200", true},  // too low
+        {1, 1, "150", true},  // lower than too low
+        {1, 1, "499", false},
+        {1, 1, "201", false},
+        {1, 1, "222", false},
+        {1, 1, "abc", false}, // not a number, so the bounds don't apply
+        {1, 2, "100", false}, // nothing known about part 2
+        {2, 1, "42", true},   // already solved
+        {2, 1, "43", true},
+        {3, 1, "1", false},
+    } {
+        err := answers.Check(test.day, test.part, test.answer)
+        if (err != nil) != test.refused {
+            t.Errorf("day %d part %d answer %s: refused %t (%v), want %t", test.day, test.part, test.answer, err != nil, err, test.refused)
+        }
+    }
+}
+
+func TestSubmit(t *testing.T) {
+    fake, srv := newFake(t)
+    c := newTestClient(srv, "secret", t)
+    for _, test := range []struct {
+        answer string
+        verdict Verdict
+    }{
+        {"5", TooLow},
+        {"9", TooHigh},
+        {"7", Correct},
+        {"7", AlreadySolved},
+    } {
+        resp, err := c.Submit(1, 1, test.answer)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if resp.Verdict != test.verdict {
+            t.Errorf("submitting %s: got %v, want %v (%q)", test.answer, resp.Verdict, test.verdict, resp.Message)
+        }
+    }
+
+    // the site's cooldown runs from the last answer to any puzzle
+    fake.Answers[fakeaoc.Part{Day: 2, Part: 1}] = "1"
+    fake.Cooldown = time.Hour
+    resp, err := c.Submit(2, 1, "1")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if resp.Verdict != TooSoon || resp.Wait <= 59 * time.Minute {
+        t.Errorf("answer inside the cooldown: got %v, wait %v", resp.Verdict, resp.Wait)
+    }
+}
@@ -0,0 +1,76 @@
+package adventclient
+
+import (
+    "errors"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Throttle spaces requests at least Interval apart. When Path is set the time
+// of the last request is kept there, so separate runs of the binary share the
+// limit.
+type Throttle struct {
+    Interval time.Duration
+    Path string
+    Now func() time.Time
+    Sleep func(time.Duration)
+
+    mu sync.Mutex
+    last time.Time
+}
+
+func NewThrottle(interval time.Duration, path string) *Throttle {
+    return &Throttle{Interval: interval, Path: path, Now: time.Now, Sleep: time.Sleep}
+}
+
+// Wait blocks until a request is allowed and records it as made.
+func (t *Throttle) Wait() error {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    last, err := t.lastRequest()
+    if err != nil {
+        return err
+    }
+    if wait := last.Add(t.Interval).Sub(t.Now()); wait > 0 {
+        t.Sleep(wait)
+    }
+    t.last = t.Now()
+    return t.save()
+}
+
+func (t *Throttle) lastRequest() (time.Time, error) {
+    if t.Path == "" {
+        return t.last, nil
+    }
+    data, err := os.ReadFile(t.Path)
+    if errors.Is(err, fs.ErrNotExist) {
+        return t.last, nil
+    }
+    if err != nil {
+        return time.Time{}, err
+    }
+    nanos, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+    if err != nil {
+        // a mangled timestamp shouldn't wedge us forever; treat it as unset
+        return t.last, nil
+    }
+    if saved := time.Unix(0, nanos); saved.After(t.last) {
+        return saved, nil
+    }
+    return t.last, nil
+}
+
+func (t *Throttle) save() error {
+    if t.Path == "" {
+        return nil
+    }
+    if err := os.MkdirAll(filepath.Dir(t.Path), 0755); err != nil {
+        return err
+    }
+    return os.WriteFile(t.Path, []byte(strconv.FormatInt(t.last.UnixNano(), 10)), 0644)
+}
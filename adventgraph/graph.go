@@ -0,0 +1,120 @@
+package adventgraph
+
+import (
+    "errors"
+    grid "advent2021/adventgrid"
+)
+
+// Edge is a step from one node to another and what it costs to take.
+type Edge[N comparable] struct {
+    To N
+    Cost int
+}
+
+// Graph is anything that can say where you can go from a node. Nodes are
+// compared with ==, so they need to be canonical: two nodes for the same
+// state must be equal.
+type Graph[N comparable] interface {
+    Neighbours(n N) []Edge[N]
+}
+
+// GraphFunc lets a plain function be used as a Graph.
+type GraphFunc[N comparable] func(n N) []Edge[N]
+
+func (f GraphFunc[N]) Neighbours(n N) []Edge[N] {
+    return f(n)
+}
+
+// Heuristic estimates the cost from a node to the goal. For A* to find the
+// cheapest path it must be consistent: never more than the cost of an edge
+// plus the estimate from the far end of it (so never an overestimate).
+type Heuristic[N comparable] func(n N) int
+
+// Manhattan is the heuristic for grids where every step costs at least 1.
+func Manhattan(goal grid.Point) Heuristic[grid.Point] {
+    return func(p grid.Point) int {
+        return abs(goal.X - p.X) + abs(goal.Y - p.Y)
+    }
+}
+
+func abs(n int) int {
+    if n < 0 {
+        return -n
+    }
+    return n
+}
+
+// Path is a route through a graph, from the start node to the goal
+// inclusive, along with its total cost.
+type Path[N comparable] struct {
+    Nodes []N
+    Cost int
+}
+
+// Stats are counters from a search, for comparing approaches.
+type Stats struct {
+    Expanded int // nodes taken off the queue and explored
+    Pushed int   // nodes put on the queue, including repeats
+}
+
+var ErrNoPath = errors.New("no path to the goal")
+
+// Dijkstra finds the cheapest path from start to the first node that
+// satisfies goal. Edge costs must not be negative.
+func Dijkstra[N comparable](g Graph[N], start N, goal func(n N) bool) (Path[N], Stats, error) {
+    return AStar(g, start, goal, nil)
+}
+
+// AStar is Dijkstra guided by a heuristic. A nil heuristic makes it plain
+// Dijkstra.
+func AStar[N comparable](g Graph[N], start N, goal func(n N) bool, h Heuristic[N]) (Path[N], Stats, error) {
+    if h == nil {
+        h = func(N) int { return 0 }
+    }
+    var stats Stats
+    best := map[N]int{start: 0}
+    prev := make(map[N]N)
+    done := make(map[N]bool)
+    q := &queue[N]{}
+    q.push(item[N]{node: start, cost: 0, priority: h(start)})
+    stats.Pushed++
+    for q.Len() > 0 {
+        curr := q.pop()
+        if done[curr.node] {
+            // a stale entry: we found a cheaper way here after queueing it
+            continue
+        }
+        done[curr.node] = true
+        stats.Expanded++
+        if goal(curr.node) {
+            return Path[N]{Nodes: walkBack(prev, start, curr.node), Cost: curr.cost}, stats, nil
+        }
+        for _, edge := range g.Neighbours(curr.node) {
+            if done[edge.To] {
+                continue
+            }
+            cost := curr.cost + edge.Cost
+            if known, ok := best[edge.To]; ok && known <= cost {
+                continue
+            }
+            best[edge.To] = cost
+            prev[edge.To] = curr.node
+            q.push(item[N]{node: edge.To, cost: cost, priority: cost + h(edge.To)})
+            stats.Pushed++
+        }
+    }
+    return Path[N]{}, stats, ErrNoPath
+}
+
+// walkBack rebuilds the path to end by following prev back to start.
+func walkBack[N comparable](prev map[N]N, start, end N) []N {
+    nodes := []N{end}
+    for node := end; node != start; {
+        node = prev[node]
+        nodes = append(nodes, node)
+    }
+    for i, j := 0, len(nodes) - 1; i < j; i, j = i + 1, j - 1 {
+        nodes[i], nodes[j] = nodes[j], nodes[i]
+    }
+    return nodes
+}
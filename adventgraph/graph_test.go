@@ -0,0 +1,155 @@
+package adventgraph
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+    grid "advent2021/adventgrid"
+)
+
+// edges is a graph written out as a map, for small hand-made tests.
+type edges map[string][]Edge[string]
+
+func (e edges) Neighbours(n string) []Edge[string] {
+    return e[n]
+}
+
+func is(goal string) func(n string) bool {
+    return func(n string) bool { return n == goal }
+}
+
+func TestDijkstra(t *testing.T) {
+    tests := []struct {
+        name string
+        g edges
+        nodes []string
+        cost int
+    }{
+        {
+            // heading for a first looks best, but a to g is the long way
+            "greedy trap",
+            edges{
+                "s": {{To: "a", Cost: 1}, {To: "b", Cost: 2}},
+                "a": {{To: "g", Cost: 10}},
+                "b": {{To: "g", Cost: 2}},
+            },
+            []string{"s", "b", "g"}, 4,
+        },
+        {
+            "longer but cheaper",
+            edges{
+                "s": {{To: "g", Cost: 9}, {To: "a", Cost: 2}},
+                "a": {{To: "b", Cost: 2}},
+                "b": {{To: "c", Cost: 2}},
+                "c": {{To: "g", Cost: 2}},
+            },
+            []string{"s", "a", "b", "c", "g"}, 8,
+        },
+        {"start is the goal", edges{"g": {{To: "a", Cost: 1}}}, []string{"g"}, 0},
+    }
+    for _, test := range tests {
+        start := test.nodes[0]
+        path, _, err := Dijkstra[string](test.g, start, is("g"))
+        if err != nil {
+            t.Errorf("%s: %v", test.name, err)
+            continue
+        }
+        if ! reflect.DeepEqual(path.Nodes, test.nodes) || path.Cost != test.cost {
+            t.Errorf("%s: got %v costing %d, expected %v costing %d", test.name, path.Nodes, path.Cost, test.nodes, test.cost)
+        }
+    }
+}
+
+func TestStaleEntry(t *testing.T) {
+    // b is queued at 4 straight from s, then again at 2 through a. The 4
+    // comes off the queue after b's been expanded and has to be skipped.
+    g := edges{
+        "s": {{To: "a", Cost: 1}, {To: "b", Cost: 4}},
+        "a": {{To: "b", Cost: 1}},
+        "b": {{To: "g", Cost: 10}},
+    }
+    path, stats, err := Dijkstra[string](g, "s", is("g"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if path.Cost != 12 || ! reflect.DeepEqual(path.Nodes, []string{"s", "a", "b", "g"}) {
+        t.Errorf("got %v costing %d, expected s a b g costing 12", path.Nodes, path.Cost)
+    }
+    if stats.Pushed != 5 || stats.Expanded != 4 {
+        t.Errorf("pushed %d and expanded %d, expected 5 and 4", stats.Pushed, stats.Expanded)
+    }
+}
+
+func TestNoPath(t *testing.T) {
+    g := edges{
+        "s": {{To: "a", Cost: 1}},
+        "a": {{To: "s", Cost: 1}},
+        "g": {{To: "s", Cost: 1}},
+    }
+    _, stats, err := Dijkstra[string](g, "s", is("g"))
+    if ! errors.Is(err, ErrNoPath) {
+        t.Errorf("expected ErrNoPath, got %v", err)
+    }
+    if stats.Expanded != 2 {
+        t.Errorf("expanded %d nodes, expected s and a", stats.Expanded)
+    }
+}
+
+func TestAStarManhattan(t *testing.T) {
+    // day 15's example: entering a cell costs its risk
+    cave, err := grid.Digits([]string{
+        "1163751742",
+        "1381373672",
+        "2136511328",
+        "3694931569",
+        "7463417111",
+        "1319128137",
+        "1359912421",
+        "3125421639",
+        "1293138521",
+        "2311944581",
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    g := GraphFunc[grid.Point](func(p grid.Point) []Edge[grid.Point] {
+        out := make([]Edge[grid.Point], 0, 4)
+        for _, n := range cave.Neighbours4(p) {
+            out = append(out, Edge[grid.Point]{To: n, Cost: cave.Get(n)})
+        }
+        return out
+    })
+    start, end := grid.Point{}, grid.Point{X: cave.Width() - 1, Y: cave.Height() - 1}
+    goal := func(p grid.Point) bool { return p == end }
+    dijkstra, dStats, err := Dijkstra[grid.Point](g, start, goal)
+    if err != nil {
+        t.Fatal(err)
+    }
+    astar, aStats, err := AStar[grid.Point](g, start, goal, Manhattan(end))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if dijkstra.Cost != 40 || astar.Cost != 40 {
+        t.Errorf("Dijkstra costs %d and A* %d, expected 40", dijkstra.Cost, astar.Cost)
+    }
+    if aStats.Expanded > dStats.Expanded {
+        t.Errorf("A* expanded %d nodes, more than Dijkstra's %d", aStats.Expanded, dStats.Expanded)
+    }
+    sum := 0
+    for _, p := range astar.Nodes[1:] {
+        sum += cave.Get(p)
+    }
+    if astar.Nodes[0] != start || astar.Nodes[len(astar.Nodes) - 1] != end || sum != astar.Cost {
+        t.Errorf("A* path %v doesn't add up to %d", astar.Nodes, astar.Cost)
+    }
+}
+
+func TestWalkBack(t *testing.T) {
+    if got := walkBack(map[string]string{}, "s", "s"); ! reflect.DeepEqual(got, []string{"s"}) {
+        t.Errorf("walking back from the start gave %v", got)
+    }
+    prev := map[string]string{"a": "s", "b": "a", "g": "b"}
+    if got := walkBack(prev, "s", "g"); ! reflect.DeepEqual(got, []string{"s", "a", "b", "g"}) {
+        t.Errorf("walking back from g gave %v", got)
+    }
+}
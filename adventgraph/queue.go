@@ -0,0 +1,50 @@
+package adventgraph
+
+import (
+    "container/heap"
+)
+
+// item is a node waiting to be expanded. cost is what it took to get there
+// and priority is cost plus the heuristic's estimate of what's left.
+type item[N comparable] struct {
+    node N
+    cost, priority int
+}
+
+// queue is a min-heap of items by priority, for container/heap.
+type queue[N comparable] []item[N]
+
+func (q queue[N]) Len() int {
+    return len(q)
+}
+
+func (q queue[N]) Less(i, j int) bool {
+    if q[i].priority == q[j].priority {
+        // prefer the one further along, it's closer to the goal
+        return q[i].cost > q[j].cost
+    }
+    return q[i].priority < q[j].priority
+}
+
+func (q queue[N]) Swap(i, j int) {
+    q[i], q[j] = q[j], q[i]
+}
+
+func (q *queue[N]) Push(x any) {
+    *q = append(*q, x.(item[N]))
+}
+
+func (q *queue[N]) Pop() any {
+    old := *q
+    last := old[len(old) - 1]
+    *q = old[:len(old) - 1]
+    return last
+}
+
+func (q *queue[N]) push(it item[N]) {
+    heap.Push(q, it)
+}
+
+func (q *queue[N]) pop() item[N] {
+    return heap.Pop(q).(item[N])
+}
@@ -0,0 +1,277 @@
+package adventgrid
+
+import (
+    "fmt"
+    "strings"
+    parser "advent2021/adventparser"
+)
+
+// Point is a position on a grid. X is the column and Y the row, counting
+// down from the top, the way puzzle inputs are laid out.
+type Point struct {
+    X, Y int
+}
+
+func (p Point) String() string {
+    return fmt.Sprintf("%d,%d", p.X, p.Y)
+}
+
+func (p Point) Add(q Point) Point {
+    return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+    return Point{p.X - q.X, p.Y - q.Y}
+}
+
+var (
+    // Orthogonal are the offsets to the 4 neighbours sharing an edge: up,
+    // right, down, left.
+    Orthogonal = []Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+    // Surrounding are the offsets to all 8 neighbours, clockwise from up.
+    Surrounding = []Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+)
+
+// Neighbours4 returns the 4 points sharing an edge with p.
+func (p Point) Neighbours4() []Point {
+    return p.offsets(Orthogonal)
+}
+
+// Neighbours8 returns the 8 points around p, diagonals included.
+func (p Point) Neighbours8() []Point {
+    return p.offsets(Surrounding)
+}
+
+func (p Point) offsets(offsets []Point) []Point {
+    points := make([]Point, len(offsets))
+    for i, offset := range offsets {
+        points[i] = p.Add(offset)
+    }
+    return points
+}
+
+// Grid is a dense, fixed-size grid backed by a single slice.
+type Grid[T any] struct {
+    width, height int
+    cells []T
+}
+
+// New makes a width by height grid of zero values.
+func New[T any](width, height int) *Grid[T] {
+    if width < 0 || height < 0 {
+        panic(fmt.Sprintf("grid can't be %dx%d", width, height))
+    }
+    return &Grid[T]{width: width, height: height, cells: make([]T, width * height)}
+}
+
+// FromRows copies rows (indexed [y][x], all the same length) into a grid.
+func FromRows[T any](rows [][]T) (*Grid[T], error) {
+    if len(rows) == 0 {
+        return New[T](0, 0), nil
+    }
+    g := New[T](len(rows[0]), len(rows))
+    for y, row := range rows {
+        if len(row) != g.width {
+            return nil, &parser.LineError{Line: y + 1, Err: fmt.Errorf("row is %d wide, expected %d", len(row), g.width)}
+        }
+        copy(g.cells[y * g.width:], row)
+    }
+    return g, nil
+}
+
+func (g *Grid[T]) Width() int {
+    return g.width
+}
+
+func (g *Grid[T]) Height() int {
+    return g.height
+}
+
+// In reports whether p is on the grid.
+func (g *Grid[T]) In(p Point) bool {
+    return p.X >= 0 && p.Y >= 0 && p.X < g.width && p.Y < g.height
+}
+
+func (g *Grid[T]) index(p Point) int {
+    if ! g.In(p) {
+        panic(fmt.Sprintf("point %v is off the %dx%d grid", p, g.width, g.height))
+    }
+    return p.Y * g.width + p.X
+}
+
+// Get returns the value at p, which must be on the grid.
+func (g *Grid[T]) Get(p Point) T {
+    return g.cells[g.index(p)]
+}
+
+// Lookup returns the value at p, or false if p is off the grid.
+func (g *Grid[T]) Lookup(p Point) (T, bool) {
+    if ! g.In(p) {
+        var zero T
+        return zero, false
+    }
+    return g.cells[p.Y * g.width + p.X], true
+}
+
+// Set stores v at p, which must be on the grid.
+func (g *Grid[T]) Set(p Point, v T) {
+    g.cells[g.index(p)] = v
+}
+
+// Fill sets every cell to v.
+func (g *Grid[T]) Fill(v T) {
+    for i := range g.cells {
+        g.cells[i] = v
+    }
+}
+
+// Row returns row y. It shares storage with the grid.
+func (g *Grid[T]) Row(y int) []T {
+    return g.cells[y * g.width:(y + 1) * g.width]
+}
+
+// Each calls fn for every point, row by row from the top left.
+func (g *Grid[T]) Each(fn func(p Point, v T)) {
+    for y := 0; y < g.height; y++ {
+        for x := 0; x < g.width; x++ {
+            fn(Point{x, y}, g.cells[y * g.width + x])
+        }
+    }
+}
+
+// Count returns how many cells satisfy keep.
+func (g *Grid[T]) Count(keep func(v T) bool) int {
+    count := 0
+    for _, v := range g.cells {
+        if keep(v) {
+            count++
+        }
+    }
+    return count
+}
+
+// Neighbours4 returns the points sharing an edge with p that are on the grid.
+func (g *Grid[T]) Neighbours4(p Point) []Point {
+    return g.inBounds(p.Neighbours4())
+}
+
+// Neighbours8 returns the points around p, diagonals included, that are on
+// the grid.
+func (g *Grid[T]) Neighbours8(p Point) []Point {
+    return g.inBounds(p.Neighbours8())
+}
+
+func (g *Grid[T]) inBounds(points []Point) []Point {
+    kept := points[:0]
+    for _, p := range points {
+        if g.In(p) {
+            kept = append(kept, p)
+        }
+    }
+    return kept
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+    clone := New[T](g.width, g.height)
+    copy(clone.cells, g.cells)
+    return clone
+}
+
+// remap builds a width by height grid where each point takes the value this
+// grid has at from(point).
+func (g *Grid[T]) remap(width, height int, from func(p Point) Point) *Grid[T] {
+    out := New[T](width, height)
+    for y := 0; y < height; y++ {
+        for x := 0; x < width; x++ {
+            out.cells[y * width + x] = g.Get(from(Point{x, y}))
+        }
+    }
+    return out
+}
+
+// Transpose swaps rows and columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+    return g.remap(g.height, g.width, func(p Point) Point { return Point{p.Y, p.X} })
+}
+
+// FlipH mirrors the grid left to right.
+func (g *Grid[T]) FlipH() *Grid[T] {
+    return g.remap(g.width, g.height, func(p Point) Point { return Point{g.width - 1 - p.X, p.Y} })
+}
+
+// FlipV mirrors the grid top to bottom.
+func (g *Grid[T]) FlipV() *Grid[T] {
+    return g.remap(g.width, g.height, func(p Point) Point { return Point{p.X, g.height - 1 - p.Y} })
+}
+
+// Rotate turns the grid a quarter turn clockwise.
+func (g *Grid[T]) Rotate() *Grid[T] {
+    return g.remap(g.height, g.width, func(p Point) Point { return Point{p.Y, g.height - 1 - p.X} })
+}
+
+// Sprint draws the grid a row per line, using cell to draw each value and
+// putting sep between the cells of a row.
+func (g *Grid[T]) Sprint(sep string, cell func(p Point, v T) string) string {
+    var b strings.Builder
+    row := make([]string, g.width)
+    for y := 0; y < g.height; y++ {
+        for x := 0; x < g.width; x++ {
+            row[x] = cell(Point{x, y}, g.cells[y * g.width + x])
+        }
+        b.WriteString(strings.Join(row, sep))
+        b.WriteByte('\n')
+    }
+    return b.String()
+}
+
+// String draws the grid with fmt's default formatting for each value, except
+// that bytes and runes are drawn as the characters they are.
+func (g *Grid[T]) String() string {
+    return g.Sprint("", func(_ Point, v T) string {
+        switch char := any(v).(type) {
+        case byte:
+            return string(char)
+        case rune:
+            return string(char)
+        }
+        return fmt.Sprint(v)
+    })
+}
+
+// Parse builds a grid from lines of single characters, converting each with
+// cell. Rows must all be the same width; errors carry the line number.
+func Parse[T any](lines []string, cell func(char byte) (T, error)) (*Grid[T], error) {
+    chars, err := parser.CharGrid(lines)
+    if err != nil {
+        return nil, err
+    }
+    if len(chars) == 0 {
+        return New[T](0, 0), nil
+    }
+    g := New[T](len(chars[0]), len(chars))
+    for y, row := range chars {
+        for x, char := range row {
+            v, err := cell(char)
+            if err != nil {
+                return nil, &parser.LineError{Line: y + 1, Err: fmt.Errorf("column %d: %w", x + 1, err)}
+            }
+            g.cells[y * g.width + x] = v
+        }
+    }
+    return g, nil
+}
+
+// Digits parses lines of single digits.
+func Digits(lines []string) (*Grid[int], error) {
+    return Parse(lines, func(char byte) (int, error) {
+        if char < '0' || char > '9' {
+            return 0, fmt.Errorf("%q is not a digit", char)
+        }
+        return int(char - '0'), nil
+    })
+}
+
+// Bytes parses lines into a grid of their characters.
+func Bytes(lines []string) (*Grid[byte], error) {
+    return Parse(lines, func(char byte) (byte, error) { return char, nil })
+}
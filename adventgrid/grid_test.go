@@ -0,0 +1,108 @@
+package adventgrid
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+    parser "advent2021/adventparser"
+)
+
+// abc is 3 wide and 2 high, so mixing up width and height shows
+var abc = []string{
+    "abc",
+    "def",
+}
+
+func TestTransforms(t *testing.T) {
+    g, err := Bytes(abc)
+    if err != nil {
+        t.Fatal(err)
+    }
+    tests := []struct {
+        name string
+        got *Grid[byte]
+        want string
+    }{
+        {"Rotate", g.Rotate(), "da\neb\nfc\n"},
+        {"Rotate twice", g.Rotate().Rotate(), "fed\ncba\n"},
+        {"Rotate four times", g.Rotate().Rotate().Rotate().Rotate(), "abc\ndef\n"},
+        {"Transpose", g.Transpose(), "ad\nbe\ncf\n"},
+        {"FlipH", g.FlipH(), "cba\nfed\n"},
+        {"FlipV", g.FlipV(), "def\nabc\n"},
+    }
+    for _, test := range tests {
+        if got := test.got.String(); got != test.want {
+            t.Errorf("%s: got\n%s\nexpected\n%s", test.name, got, test.want)
+        }
+    }
+    if g.String() != "abc\ndef\n" {
+        t.Errorf("transforms changed the original grid:\n%s", g)
+    }
+}
+
+func TestNeighbours(t *testing.T) {
+    g := New[int](3, 2)
+    tests := []struct {
+        p Point
+        four, eight []Point
+    }{
+        {Point{0, 0}, []Point{{1, 0}, {0, 1}}, []Point{{1, 0}, {1, 1}, {0, 1}}},
+        {Point{2, 0}, []Point{{2, 1}, {1, 0}}, []Point{{2, 1}, {1, 1}, {1, 0}}},
+        {Point{2, 1}, []Point{{2, 0}, {1, 1}}, []Point{{2, 0}, {1, 1}, {1, 0}}},
+        {Point{0, 1}, []Point{{0, 0}, {1, 1}}, []Point{{0, 0}, {1, 0}, {1, 1}}},
+        // the middle of the top edge
+        {Point{1, 0}, []Point{{2, 0}, {1, 1}, {0, 0}}, []Point{{2, 0}, {2, 1}, {1, 1}, {0, 1}, {0, 0}}},
+    }
+    for _, test := range tests {
+        if got := g.Neighbours4(test.p); ! reflect.DeepEqual(got, test.four) {
+            t.Errorf("Neighbours4(%v) = %v, expected %v", test.p, got, test.four)
+        }
+        if got := g.Neighbours8(test.p); ! reflect.DeepEqual(got, test.eight) {
+            t.Errorf("Neighbours8(%v) = %v, expected %v", test.p, got, test.eight)
+        }
+    }
+    if got := (Point{0, 0}).Neighbours8(); len(got) != 8 {
+        t.Errorf("a point on its own has %d neighbours, expected 8: %v", len(got), got)
+    }
+}
+
+func TestParseRagged(t *testing.T) {
+    _, err := Digits([]string{"123", "456", "78", "901"})
+    var lineErr *parser.LineError
+    if ! errors.As(err, &lineErr) {
+        t.Fatalf("expected a LineError, got %v", err)
+    }
+    if lineErr.Line != 3 {
+        t.Errorf("error is on line %d, expected 3: %v", lineErr.Line, err)
+    }
+    _, err = Digits([]string{"123", "4x6"})
+    if ! errors.As(err, &lineErr) || lineErr.Line != 2 {
+        t.Errorf("expected a bad digit on line 2, got %v", err)
+    }
+}
+
+func TestSparse(t *testing.T) {
+    s := NewSparse[byte]()
+    if min, max := s.Bounds(); min != (Point{}) || max != (Point{}) {
+        t.Errorf("empty bounds are %v to %v", min, max)
+    }
+    s.Set(Point{-2, 1}, 'a')
+    s.Set(Point{1, -3}, 'b')
+    s.Set(Point{0, 0}, 'c')
+    min, max := s.Bounds()
+    if min != (Point{-2, -3}) || max != (Point{1, 1}) {
+        t.Errorf("bounds are %v to %v, expected -2,-3 to 1,1", min, max)
+    }
+    g, offset := s.Dense()
+    if offset != min || g.Width() != 4 || g.Height() != 5 {
+        t.Fatalf("dense grid is %dx%d at %v, expected 4x5 at %v", g.Width(), g.Height(), offset, min)
+    }
+    s.Each(func(p Point, v byte) {
+        if got := g.Get(p.Sub(offset)); got != v {
+            t.Errorf("%v is %q on the dense grid, expected %q", p, got, v)
+        }
+    })
+    if count := g.Count(func(v byte) bool { return v != 0 }); count != s.Len() {
+        t.Errorf("dense grid has %d points set, expected %d", count, s.Len())
+    }
+}
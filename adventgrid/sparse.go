@@ -0,0 +1,110 @@
+package adventgrid
+
+import (
+    "strings"
+)
+
+// Sparse is a grid that only stores the points that have been set, for
+// puzzles whose coordinates are unbounded or mostly empty.
+type Sparse[T any] struct {
+    points map[Point]T
+}
+
+func NewSparse[T any]() *Sparse[T] {
+    return &Sparse[T]{points: make(map[Point]T)}
+}
+
+// Len returns how many points are set.
+func (s *Sparse[T]) Len() int {
+    return len(s.points)
+}
+
+// Get returns the value at p, or the zero value if it isn't set.
+func (s *Sparse[T]) Get(p Point) T {
+    return s.points[p]
+}
+
+// Lookup returns the value at p and whether it is set.
+func (s *Sparse[T]) Lookup(p Point) (T, bool) {
+    v, ok := s.points[p]
+    return v, ok
+}
+
+func (s *Sparse[T]) Set(p Point, v T) {
+    s.points[p] = v
+}
+
+func (s *Sparse[T]) Delete(p Point) {
+    delete(s.points, p)
+}
+
+// Each calls fn for every point that is set, in no particular order. fn may
+// set and delete points; like ranging over a map, points it adds may or may
+// not be visited.
+func (s *Sparse[T]) Each(fn func(p Point, v T)) {
+    for p, v := range s.points {
+        fn(p, v)
+    }
+}
+
+// Bounds returns the top left and bottom right corners of the smallest
+// rectangle holding every point that is set. Both are the zero Point when
+// nothing is.
+func (s *Sparse[T]) Bounds() (min, max Point) {
+    first := true
+    for p := range s.points {
+        if first {
+            min, max = p, p
+            first = false
+            continue
+        }
+        if p.X < min.X {
+            min.X = p.X
+        }
+        if p.Y < min.Y {
+            min.Y = p.Y
+        }
+        if p.X > max.X {
+            max.X = p.X
+        }
+        if p.Y > max.Y {
+            max.Y = p.Y
+        }
+    }
+    return min, max
+}
+
+// Dense copies the points inside Bounds into a Grid, along with the offset
+// to add to a point on the grid to get back to the sparse point.
+func (s *Sparse[T]) Dense() (*Grid[T], Point) {
+    if len(s.points) == 0 {
+        return New[T](0, 0), Point{}
+    }
+    min, max := s.Bounds()
+    g := New[T](max.X - min.X + 1, max.Y - min.Y + 1)
+    for p, v := range s.points {
+        g.Set(p.Sub(min), v)
+    }
+    return g, min
+}
+
+// Sprint draws the points inside Bounds a row per line, using cell to draw
+// each point (ok is false for points that aren't set).
+func (s *Sparse[T]) Sprint(sep string, cell func(p Point, v T, ok bool) string) string {
+    if len(s.points) == 0 {
+        return ""
+    }
+    min, max := s.Bounds()
+    var b strings.Builder
+    row := make([]string, max.X - min.X + 1)
+    for y := min.Y; y <= max.Y; y++ {
+        for x := min.X; x <= max.X; x++ {
+            p := Point{x, y}
+            v, ok := s.points[p]
+            row[x - min.X] = cell(p, v, ok)
+        }
+        b.WriteString(strings.Join(row, sep))
+        b.WriteByte('\n')
+    }
+    return b.String()
+}
@@ -1,76 +1,178 @@
 package adventlogger
 
 import (
+    "fmt"
     "io"
-    "io/ioutil"
     "os"
-    "fmt"
+    "strings"
+    "time"
     "github.com/sirupsen/logrus"
 )
 
-type logger struct {
+const (
+    LevelEnv = "ADVENT_LOG"
+    FormatEnv = "ADVENT_LOG_FORMAT"
+    FileEnv = "ADVENT_LOG_FILE"
+)
+
+// Config picks what gets logged, how it looks and where it goes.
+type Config struct {
+    Level string  // trace, debug, info, warning or error; info if empty
+    Format string // text (the default) or json
+    File string   // if set, everything is logged here instead of the terminal
+}
+
+// ConfigFromEnv reads a Config from ADVENT_LOG, ADVENT_LOG_FORMAT and
+// ADVENT_LOG_FILE.
+func ConfigFromEnv() Config {
+    return Config{
+        Level: os.Getenv(LevelEnv),
+        Format: os.Getenv(FormatEnv),
+        File: os.Getenv(FileEnv),
+    }
+}
+
+// Fields are extra key/value pairs attached to every line a Logger writes.
+type Fields = logrus.Fields
+
+type Logger struct {
     Trace   *logrus.Logger
     Info    *logrus.Logger
     Warning *logrus.Logger
     Error   *logrus.Logger
+    fields Fields
+    start time.Time // lines get an elapsed field when this is set
+}
+
+// WithFields returns a Logger writing to the same places whose lines also
+// carry the given fields (on top of any this one already has).
+func (log *Logger) WithFields(fields Fields) *Logger {
+    child := *log
+    child.fields = make(Fields, len(log.fields) + len(fields))
+    for k, v := range log.fields {
+        child.fields[k] = v
+    }
+    for k, v := range fields {
+        child.fields[k] = v
+    }
+    return &child
+}
+
+// WithField is WithFields for a single field.
+func (log *Logger) WithField(key string, value interface{}) *Logger {
+    return log.WithFields(Fields{key: value})
+}
+
+// WithDay returns a Logger for one part of a day: its lines carry day and
+// part fields, plus elapsed, the time since WithDay was called.
+func (log *Logger) WithDay(day, part int) *Logger {
+    child := log.WithFields(Fields{"day": day, "part": part})
+    child.start = time.Now()
+    return child
+}
+
+// entry attaches our fields to a line about to be written by one of the
+// level loggers.
+func (log *Logger) entry(to *logrus.Logger) *logrus.Entry {
+    entry := logrus.NewEntry(to)
+    if len(log.fields) > 0 {
+        entry = entry.WithFields(log.fields)
+    }
+    if ! log.start.IsZero() {
+        entry = entry.WithField("elapsed", time.Since(log.start).Round(time.Microsecond).String())
+    }
+    return entry
+}
+
+// New builds a Logger from cfg. Everything goes to stderr, leaving stdout for
+// answers and anything the tools write there, unless a file is given, in which
+// case everything goes to the file and errors are repeated on stderr so they
+// aren't missed.
+func New(cfg Config) (*Logger, error) {
+    level := logrus.InfoLevel
+    if cfg.Level != "" {
+        var err error
+        if level, err = logrus.ParseLevel(cfg.Level); err != nil {
+            return nil, fmt.Errorf("bad log level %q: want trace, debug, info, warning or error", cfg.Level)
+        }
+    }
+    var formatter logrus.Formatter
+    switch strings.ToLower(cfg.Format) {
+    case "", "text":
+        formatter = &logrus.TextFormatter{DisableTimestamp: true}
+    case "json":
+        formatter = &logrus.JSONFormatter{
+            DisableHTMLEscape: true,
+            FieldMap: logrus.FieldMap{
+                logrus.FieldKeyTime: "timestamp",
+                logrus.FieldKeyLevel: "level",
+                logrus.FieldKeyMsg: "message",
+            },
+        }
+    default:
+        return nil, fmt.Errorf("bad log format %q: want text or json", cfg.Format)
+    }
+    var out, errOut io.Writer = os.Stderr, os.Stderr
+    if cfg.File != "" {
+        file, err := os.OpenFile(cfg.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+        if err != nil {
+            return nil, err
+        }
+        out, errOut = file, io.MultiWriter(file, os.Stderr)
+    }
+    return GetLoggers(out, out, out, errOut, formatter, level), nil
 }
 
 func GetLoggers(traceHandle io.Writer,
                 infoHandle io.Writer,
                 warningHandle io.Writer,
-                errorHandle io.Writer) *(logger) {
-    formatter := &logrus.JSONFormatter{
-        DisableHTMLEscape: true,
-        FieldMap: logrus.FieldMap{
-            logrus.FieldKeyTime: "timestamp",
-            logrus.FieldKeyLevel: "level",
-            logrus.FieldKeyMsg: "message",
-        },
-    }
-	custLogger := logger{
-        Trace: &logrus.Logger{
-            Out: traceHandle,
+                errorHandle io.Writer,
+                formatter logrus.Formatter,
+                level logrus.Level) *Logger {
+    newLogger := func(out io.Writer) *logrus.Logger {
+        return &logrus.Logger{
+            Out: out,
             Formatter: formatter,
             Hooks: make(logrus.LevelHooks),
-            Level: logrus.DebugLevel,
-          },
-         Info: &logrus.Logger{
-            Out: infoHandle,
-            Formatter: formatter,
-            Hooks: make(logrus.LevelHooks),
-            Level: logrus.InfoLevel,
-          },
-         Warning: &logrus.Logger{
-            Out: warningHandle,
-            Formatter: formatter,
-            Hooks: make(logrus.LevelHooks),
-            Level: logrus.WarnLevel,
-          },
-         Error: &logrus.Logger{
-            Out: errorHandle,
-            Formatter: formatter,
-            Hooks: make(logrus.LevelHooks),
-            Level: logrus.ErrorLevel,
-          },
+            Level: level,
+        }
+    }
+    custLogger := Logger{
+        Trace: newLogger(traceHandle),
+        Info: newLogger(infoHandle),
+        Warning: newLogger(warningHandle),
+        Error: newLogger(errorHandle),
     }
     return &custLogger
 }
 
-var Logs = GetLoggers(ioutil.Discard, os.Stdout, os.Stdout, os.Stderr)
+// Logs is the logger the days share. It starts out configured from the
+// environment (or the defaults, if that's invalid, with LogsErr saying why);
+// commands that take logging flags replace it with one from New, but only when
+// the flags change something, so ADVENT_LOG_FILE isn't opened twice.
+var Logs, LogsErr = defaultLogger()
+
+func defaultLogger() (*Logger, error) {
+    logs, err := New(ConfigFromEnv())
+    if err != nil {
+        logs, _ = New(Config{})
+    }
+    return logs, err
+}
 
-func (log *logger) Tracef(format string, v ...interface{}) {
+func (log *Logger) Tracef(format string, v ...interface{}) {
     defer func() {
         // Rescue ourselves in case Flags hasn't been set up yet but is trying
         // to log
         if r := recover(); r != nil {
-            log.Trace.Debug(fmt.Sprintf(format, v...))
+            log.Trace.Trace(fmt.Sprintf(format, v...))
         }
     }()
     log.
-    Trace.
-    Debug(fmt.Sprintf(format, v...))
+    entry(log.Trace).
+    Trace(fmt.Sprintf(format, v...))
 }
-func (log *logger) Infof(format string, v ...interface{}) {
+func (log *Logger) Infof(format string, v ...interface{}) {
     defer func() {
         // Rescue ourselves in case Flags hasn't been set up yet but is trying
         // to log
@@ -79,10 +181,10 @@ func (log *logger) Infof(format string, v ...interface{}) {
         }
     }()
     log.
-    Info.
+    entry(log.Info).
     Info(fmt.Sprintf(format, v...))
 }
-func (log *logger) Warningf(format string, v ...interface{}) {
+func (log *Logger) Warningf(format string, v ...interface{}) {
     defer func() {
         // Rescue ourselves in case Flags hasn't been set up yet but is trying
         // to log
@@ -91,10 +193,10 @@ func (log *logger) Warningf(format string, v ...interface{}) {
         }
     }()
     log.
-    Warning.
+    entry(log.Warning).
     Warning(fmt.Sprintf(format, v...))
 }
-func (log *logger) Errorf(format string, v ...interface{}) {
+func (log *Logger) Errorf(format string, v ...interface{}) {
     defer func() {
         // Rescue ourselves in case Flags hasn't been set up yet but is trying
         // to log
@@ -103,6 +205,6 @@ func (log *logger) Errorf(format string, v ...interface{}) {
         }
     }()
     log.
-    Error.
+    entry(log.Error).
     Error(fmt.Sprintf(format, v...))
 }
@@ -0,0 +1,111 @@
+package adventparser
+
+import (
+    "fmt"
+    "reflect"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Match fills the struct pointed to by dst from the named groups of re
+// matched against line. A field is filled from the group named by its
+// `parse:"name"` tag, or else from the group with the field's name, ignoring
+// case. Fields may be strings, ints, uints, floats or bools; groups that did
+// not take part in the match leave their field untouched.
+func Match(re *regexp.Regexp, line string, dst interface{}) error {
+    target := reflect.ValueOf(dst)
+    if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+        return fmt.Errorf("Match needs a pointer to a struct, got %T", dst)
+    }
+    return match(re, line, target.Elem())
+}
+
+// MatchLines appends one element to the slice pointed to by dst for every
+// line, filled as by Match. Lines that do not match are reported with their
+// line number, and leave dst as it was.
+func MatchLines(re *regexp.Regexp, lines []string, dst interface{}) error {
+    target := reflect.ValueOf(dst)
+    if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Slice || target.Elem().Type().Elem().Kind() != reflect.Struct {
+        return fmt.Errorf("MatchLines needs a pointer to a slice of structs, got %T", dst)
+    }
+    elemType := target.Elem().Type().Elem()
+    matched := reflect.MakeSlice(target.Elem().Type(), 0, len(lines))
+    for i, line := range lines {
+        elem := reflect.New(elemType).Elem()
+        if err := match(re, line, elem); err != nil {
+            return atLine(i, err)
+        }
+        matched = reflect.Append(matched, elem)
+    }
+    target.Elem().Set(reflect.AppendSlice(target.Elem(), matched))
+    return nil
+}
+
+func match(re *regexp.Regexp, line string, target reflect.Value) error {
+    submatches := re.FindStringSubmatchIndex(line)
+    if submatches == nil {
+        return fmt.Errorf("%q does not match %s", line, re)
+    }
+    groups := make(map[string]int)
+    for i, name := range re.SubexpNames() {
+        if name != "" {
+            groups[strings.ToLower(name)] = i
+        }
+    }
+    structType := target.Type()
+    for i := 0; i < structType.NumField(); i++ {
+        field := structType.Field(i)
+        if field.PkgPath != "" {
+            // unexported, reflect can't set it
+            continue
+        }
+        name, ok := field.Tag.Lookup("parse")
+        if ! ok {
+            name = field.Name
+        }
+        group, ok := groups[strings.ToLower(name)]
+        if ! ok || submatches[2 * group] < 0 {
+            continue
+        }
+        text := line[submatches[2 * group]:submatches[2 * group + 1]]
+        if err := setField(target.Field(i), text); err != nil {
+            return fmt.Errorf("field %s: %w", field.Name, err)
+        }
+    }
+    return nil
+}
+
+func setField(field reflect.Value, text string) error {
+    switch field.Kind() {
+    case reflect.String:
+        field.SetString(text)
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        value, err := strconv.ParseInt(text, 10, field.Type().Bits())
+        if err != nil {
+            return err
+        }
+        field.SetInt(value)
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        value, err := strconv.ParseUint(text, 10, field.Type().Bits())
+        if err != nil {
+            return err
+        }
+        field.SetUint(value)
+    case reflect.Float32, reflect.Float64:
+        value, err := strconv.ParseFloat(text, field.Type().Bits())
+        if err != nil {
+            return err
+        }
+        field.SetFloat(value)
+    case reflect.Bool:
+        value, err := strconv.ParseBool(text)
+        if err != nil {
+            return err
+        }
+        field.SetBool(value)
+    default:
+        return fmt.Errorf("can't parse into a %s", field.Type())
+    }
+    return nil
+}
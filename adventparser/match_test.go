@@ -0,0 +1,98 @@
+package adventparser
+
+import (
+    "errors"
+    "reflect"
+    "regexp"
+    "testing"
+)
+
+type instruction struct {
+    Op string
+    Amount int `parse:"n"`
+    Small uint8
+    Scale float64
+    On bool
+    Label string // only set by the optional group
+    hidden int
+}
+
+var instructionRe = regexp.MustCompile(`^(?P<op>\w+) (?P<n>-?\d+)(?: (?P<small>\d+) (?P<scale>[\d.]+) (?P<on>\w+))?(?: #(?P<label>\w+))?$`)
+
+func TestMatch(t *testing.T) {
+    tests := []struct {
+        line string
+        want instruction
+        ok bool
+    }{
+        {"forward 5", instruction{Op: "forward", Amount: 5, Label: "unset"}, true},
+        {"up -3 7 0.5 true #x", instruction{Op: "up", Amount: -3, Small: 7, Scale: 0.5, On: true, Label: "x"}, true},
+        // groups that aren't in the match leave Label alone
+        {"down 0 255 1 false", instruction{Op: "down", Small: 255, Scale: 1, Label: "unset"}, true},
+        {"down", instruction{}, false},
+        {"down 1 256 1 true", instruction{}, false},
+        {"down 1 2 1 maybe", instruction{}, false},
+        {"down 99999999999999999999", instruction{}, false},
+    }
+    for _, test := range tests {
+        got := instruction{Label: "unset", hidden: 1}
+        err := Match(instructionRe, test.line, &got)
+        if (err == nil) != test.ok {
+            t.Errorf("%q: got error %v, expected ok %v", test.line, err, test.ok)
+            continue
+        }
+        if ! test.ok {
+            continue
+        }
+        test.want.hidden = 1
+        if got != test.want {
+            t.Errorf("%q: got %+v, expected %+v", test.line, got, test.want)
+        }
+    }
+    var notStruct int
+    for _, dst := range []interface{}{instruction{}, &notStruct, nil} {
+        if err := Match(instructionRe, "forward 5", dst); err == nil {
+            t.Errorf("Match into %T should fail", dst)
+        }
+    }
+    var unsupported struct{ Op []string }
+    if err := Match(instructionRe, "forward 5", &unsupported); err == nil {
+        t.Errorf("Match into a []string field should fail")
+    }
+}
+
+func TestMatchLines(t *testing.T) {
+    tests := []struct {
+        lines []string
+        want []instruction
+        errLine int // 0 for no error
+    }{
+        {[]string{}, []instruction{{Op: "keep"}}, 0},
+        {[]string{"forward 5", "up 3"}, []instruction{{Op: "keep"}, {Op: "forward", Amount: 5}, {Op: "up", Amount: 3}}, 0},
+        {[]string{"forward 5", "up", "down 2"}, []instruction{{Op: "keep"}}, 2},
+        {[]string{"forward 5", "up 3", "down x"}, []instruction{{Op: "keep"}}, 3},
+    }
+    for _, test := range tests {
+        got := []instruction{{Op: "keep"}}
+        err := MatchLines(instructionRe, test.lines, &got)
+        var lineErr *LineError
+        switch {
+        case test.errLine == 0 && err != nil:
+            t.Errorf("%q: %v", test.lines, err)
+        case test.errLine != 0 && ! errors.As(err, &lineErr):
+            t.Errorf("%q: expected an error on line %d, got %v", test.lines, test.errLine, err)
+        case test.errLine != 0 && lineErr.Line != test.errLine:
+            t.Errorf("%q: error is on line %d, expected %d: %v", test.lines, lineErr.Line, test.errLine, err)
+        }
+        if ! reflect.DeepEqual(got, test.want) {
+            t.Errorf("%q: got %+v, expected %+v", test.lines, got, test.want)
+        }
+    }
+    var notSlice instruction
+    var notStructs []int
+    for _, dst := range []interface{}{&notSlice, &notStructs, []instruction{}} {
+        if err := MatchLines(instructionRe, []string{"forward 5"}, dst); err == nil {
+            t.Errorf("MatchLines into %T should fail", dst)
+        }
+    }
+}
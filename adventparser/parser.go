@@ -0,0 +1,137 @@
+package adventparser
+
+import (
+    "errors"
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+// LineError reports the line of the input that failed to parse.
+type LineError struct {
+    Line int // 1-indexed
+    Err error
+}
+
+func (e *LineError) Error() string {
+    return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+    return e.Err
+}
+
+// atLine wraps err as coming from the 0-indexed line i.
+func atLine(i int, err error) error {
+    if err == nil {
+        return nil
+    }
+    return &LineError{Line: i + 1, Err: err}
+}
+
+var (
+    separatorsMu sync.Mutex
+    separators = make(map[string]*regexp.Regexp)
+)
+
+func separator(sep string) *regexp.Regexp {
+    separatorsMu.Lock()
+    defer separatorsMu.Unlock()
+    if reg, ok := separators[sep]; ok {
+        return reg
+    }
+    reg := regexp.MustCompile(sep)
+    separators[sep] = reg
+    return reg
+}
+
+// Ints splits s on the sep regular expression (surrounding whitespace is
+// ignored) and converts every field to an int.
+func Ints(s string, sep string) ([]int, error) {
+    fields := separator(sep).Split(strings.TrimSpace(s), -1)
+    ints := make([]int, 0, len(fields))
+    for _, field := range fields {
+        integer, err := strconv.Atoi(strings.TrimSpace(field))
+        if err != nil {
+            return nil, err
+        }
+        ints = append(ints, integer)
+    }
+    return ints, nil
+}
+
+// IntRows is Ints over every line, one row per line.
+func IntRows(lines []string, sep string) ([][]int, error) {
+    rows := make([][]int, 0, len(lines))
+    for i, line := range lines {
+        row, err := Ints(line, sep)
+        if err != nil {
+            return nil, atLine(i, err)
+        }
+        rows = append(rows, row)
+    }
+    return rows, nil
+}
+
+// IntLines is Ints over every line, flattened into a single slice.
+func IntLines(lines []string, sep string) ([]int, error) {
+    ints := make([]int, 0)
+    for i, line := range lines {
+        lineInts, err := Ints(line, sep)
+        if err != nil {
+            return nil, atLine(i, err)
+        }
+        ints = append(ints, lineInts...)
+    }
+    return ints, nil
+}
+
+// CharGrid splits lines into rows of bytes, indexed [row][column], insisting
+// every row is the same width.
+func CharGrid(lines []string) ([][]byte, error) {
+    grid := make([][]byte, 0, len(lines))
+    for i, line := range lines {
+        if i > 0 && len(line) != len(grid[0]) {
+            return nil, atLine(i, fmt.Errorf("row is %d wide, expected %d", len(line), len(grid[0])))
+        }
+        grid = append(grid, []byte(line))
+    }
+    return grid, nil
+}
+
+// Block is a run of non-blank lines.
+type Block struct {
+    Line int // line number of the first line in the whole input, 1-indexed
+    Lines []string
+}
+
+// Blocks splits lines on blank lines. Runs of blank lines, and blank lines at
+// either end, do not produce empty blocks.
+func Blocks(lines []string) []Block {
+    blocks := make([]Block, 0)
+    var block *Block
+    for i, line := range lines {
+        if strings.TrimSpace(line) == "" {
+            block = nil
+            continue
+        }
+        if block == nil {
+            blocks = append(blocks, Block{Line: i + 1})
+            block = &blocks[len(blocks) - 1]
+        }
+        block.Lines = append(block.Lines, line)
+    }
+    return blocks
+}
+
+// Err renumbers a *LineError from parsing the block's lines so that it points
+// at the line in the whole input. Other errors are returned unchanged.
+func (b Block) Err(err error) error {
+    var lineErr *LineError
+    if errors.As(err, &lineErr) {
+        return &LineError{Line: lineErr.Line + b.Line - 1, Err: lineErr.Err}
+    }
+    return err
+}
@@ -0,0 +1,67 @@
+package adventparser
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestBlocks(t *testing.T) {
+    tests := []struct {
+        name string
+        lines []string
+        want []Block
+    }{
+        {"nothing", []string{}, []Block{}},
+        {"only blank", []string{"", "  ", ""}, []Block{}},
+        {"one", []string{"a", "b"}, []Block{{Line: 1, Lines: []string{"a", "b"}}}},
+        {
+            "blank at both ends and a run in the middle",
+            []string{"", "a", "", "", "b", "c", "\t", ""},
+            []Block{{Line: 2, Lines: []string{"a"}}, {Line: 5, Lines: []string{"b", "c"}}},
+        },
+        {
+            "many",
+            []string{"a", "", "b", "", "c", "", "d"},
+            []Block{{Line: 1, Lines: []string{"a"}}, {Line: 3, Lines: []string{"b"}}, {Line: 5, Lines: []string{"c"}}, {Line: 7, Lines: []string{"d"}}},
+        },
+    }
+    for _, test := range tests {
+        if got := Blocks(test.lines); ! reflect.DeepEqual(got, test.want) {
+            t.Errorf("%s: got %+v, expected %+v", test.name, got, test.want)
+        }
+    }
+}
+
+func TestBlockErr(t *testing.T) {
+    block := Block{Line: 10, Lines: []string{"a", "b", "c"}}
+    plain := errors.New("plain")
+    tests := []struct {
+        name string
+        err error
+        line int // 0 when it shouldn't be a LineError
+    }{
+        {"first line", &LineError{Line: 1, Err: plain}, 10},
+        {"third line", &LineError{Line: 3, Err: plain}, 12},
+        {"wrapped", fmt.Errorf("parsing: %w", &LineError{Line: 2, Err: plain}), 11},
+        {"no line", plain, 0},
+        {"nil", nil, 0},
+    }
+    for _, test := range tests {
+        err := block.Err(test.err)
+        var lineErr *LineError
+        if test.line == 0 {
+            if err != test.err {
+                t.Errorf("%s: %v came back as %v", test.name, test.err, err)
+            }
+            continue
+        }
+        if ! errors.As(err, &lineErr) || lineErr.Line != test.line {
+            t.Errorf("%s: got %v, expected line %d", test.name, err, test.line)
+        }
+        if ! errors.Is(err, plain) {
+            t.Errorf("%s: %v no longer wraps the original error", test.name, err)
+        }
+    }
+}
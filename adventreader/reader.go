@@ -2,35 +2,74 @@ package adventreader
 
 import (
     "bufio"
-    "bytes"
-	"os"
-	"runtime"
-	"strings"
+    "errors"
+    "io"
+    "strings"
 )
 
-func FromFile(filename string, offset ...int) []byte {
-    skip := 1
-    if len(offset) > 0 {
-        skip = offset[0]
+// ReadBytes returns the whole of the input.
+func ReadBytes(src Source) ([]byte, error) {
+    input, err := src.Open()
+    if err != nil {
+        return nil, err
     }
-	_, file, _, _ := runtime.Caller(skip)
-    pathTokens := strings.Split(file, "/")
-    dir := strings.Join(pathTokens[:len(pathTokens)-1], "/")
-	data, err := os.ReadFile(dir + "/" + filename)
-	if err != nil {
-		panic(err)
-	}
-	return data
+    defer input.Close()
+    return io.ReadAll(input)
 }
 
-func LinesFromFile(filename string) []string {
-    input := bytes.NewBuffer(FromFile(filename, 2))
-    scanner := bufio.NewScanner(input)
-    var lines []string
-    for scanner.Scan() {
-        lines = append(lines, scanner.Text())
+// EachLine calls fn for every line of the input, without the trailing line
+// ending, stopping at the first error. Unlike bufio.Scanner there is no limit
+// on line length.
+func EachLine(src Source, fn func(line string) error) error {
+    input, err := src.Open()
+    if err != nil {
+        return err
+    }
+    defer input.Close()
+    buffered := bufio.NewReader(input)
+    for {
+        line, err := buffered.ReadString('\n')
+        if len(line) > 0 {
+            line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+            if fnErr := fn(line); fnErr != nil {
+                return fnErr
+            }
+        }
+        if errors.Is(err, io.EOF) {
+            return nil
+        }
+        if err != nil {
+            return err
+        }
     }
-    return lines
 }
 
+// ReadLines returns every line of the input.
+func ReadLines(src Source) ([]string, error) {
+    var lines []string
+    err := EachLine(src, func(line string) error {
+        lines = append(lines, line)
+        return nil
+    })
+    return lines, err
+}
 
+// FromFile is ReadBytes for callers that would rather panic than handle a
+// missing input.
+func FromFile(src Source) []byte {
+    data, err := ReadBytes(src)
+    if err != nil {
+        panic(err)
+    }
+    return data
+}
+
+// LinesFromFile is ReadLines for callers that would rather panic than handle a
+// missing input.
+func LinesFromFile(src Source) []string {
+    lines, err := ReadLines(src)
+    if err != nil {
+        panic(err)
+    }
+    return lines
+}
@@ -0,0 +1,58 @@
+package adventreader
+
+import (
+    "errors"
+    "fmt"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestReadLines(t *testing.T) {
+    long := strings.Repeat("0123456789", 20000) // 200 KB, well past bufio.Scanner's 64 KiB
+    tests := []struct {
+        name string
+        content string
+        want []string
+    }{
+        {"LF", "a\nbb\n\nccc\n", []string{"a", "bb", "", "ccc"}},
+        {"CRLF", "a\r\nbb\r\n\r\nccc\r\n", []string{"a", "bb", "", "ccc"}},
+        {"mixed, no final newline", "a\r\nbb\nccc", []string{"a", "bb", "ccc"}},
+        {"a carriage return inside a line stays", "a\rb\r\n", []string{"a\rb"}},
+        {"long line", "x\n" + long + "\ny\n", []string{"x", long, "y"}},
+        {"long CRLF line", long + "\r\n" + long, []string{long, long}},
+        {"empty", "", nil},
+    }
+    root := inputs(t)
+    for i, test := range tests {
+        name := filepath.Join(root, fmt.Sprintf("input%d.txt", i))
+        write(t, name, test.content)
+        got, err := ReadLines(SourceFor(1, name))
+        if err != nil {
+            t.Errorf("%s: %v", test.name, err)
+            continue
+        }
+        if ! reflect.DeepEqual(got, test.want) {
+            t.Errorf("%s: got %d lines %.60q, expected %d lines %.60q", test.name, len(got), got, len(test.want), test.want)
+        }
+    }
+}
+
+func TestEachLineStops(t *testing.T) {
+    root := inputs(t)
+    name := filepath.Join(root, "input.txt")
+    write(t, name, "1\n2\n3\n")
+    stop := errors.New("stop")
+    seen := make([]string, 0)
+    err := EachLine(SourceFor(1, name), func(line string) error {
+        seen = append(seen, line)
+        if line == "2" {
+            return stop
+        }
+        return nil
+    })
+    if err != stop || ! reflect.DeepEqual(seen, []string{"1", "2"}) {
+        t.Errorf("got %v after seeing %v, expected to stop after 1 and 2", err, seen)
+    }
+}
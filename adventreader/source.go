@@ -0,0 +1,144 @@
+package adventreader
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "path"
+    "path/filepath"
+    "strings"
+    "sync"
+)
+
+// InputDirEnv names the environment variable pointing at a directory of
+// inputs laid out as dayNN/input.txt, dayNN/test.txt and so on.
+const InputDirEnv = "ADVENT_INPUT_DIR"
+
+// Stdin is the Path that reads a puzzle input from standard input.
+const Stdin = "-"
+
+var embedded fs.FS
+
+var searchDirs []string
+
+// stdin is read once and replayed, since every part of a day reads its input.
+var (
+    stdinOnce sync.Once
+    stdinData []byte
+    stdinErr error
+)
+
+// Embed registers a file system (typically an embed.FS) holding inputs in the
+// same dayNN/name layout as ADVENT_INPUT_DIR, so a compiled binary can carry
+// its inputs with it.
+func Embed(fsys fs.FS) {
+    embedded = fsys
+}
+
+// AddSearchDir adds a directory laid out like ADVENT_INPUT_DIR (such as the
+// cache that downloaded inputs are kept in) to search after it.
+func AddSearchDir(dir string) {
+    searchDirs = append(searchDirs, dir)
+}
+
+// Source identifies a puzzle input. When Path is set it is used as is: "-"
+// reads stdin and anything else is opened relative to the working directory.
+// Otherwise Name is looked up for the given Day, in order, in:
+//
+//   1. $ADVENT_INPUT_DIR/dayNN/Name
+//   2. dayNN/Name under each directory added with AddSearchDir
+//   3. dayNN/Name in the file system registered with Embed
+//   4. days/dayNN/Name under the working directory (running from the repo)
+type Source struct {
+    Day int
+    Name string
+    Path string
+}
+
+// SourceFor interprets a user-supplied input argument for a day: "-" and
+// anything that looks like a path are taken literally, bare file names such as
+// "test.txt" are searched for.
+func SourceFor(day int, input string) Source {
+    if input == Stdin || strings.ContainsRune(input, os.PathSeparator) || strings.ContainsRune(input, '/') {
+        return Source{Day: day, Path: input}
+    }
+    return Source{Day: day, Name: input}
+}
+
+func (s Source) String() string {
+    if s.Path == Stdin {
+        return "stdin"
+    }
+    if s.Path != "" {
+        return s.Path
+    }
+    return fmt.Sprintf("day %d %s", s.Day, s.Name)
+}
+
+func (s Source) dayDir() string {
+    return fmt.Sprintf("day%02d", s.Day)
+}
+
+// Open resolves the source and opens it for reading.
+func (s Source) Open() (io.ReadCloser, error) {
+    if s.Path == Stdin {
+        stdinOnce.Do(func() {
+            stdinData, stdinErr = io.ReadAll(os.Stdin)
+        })
+        if stdinErr != nil {
+            return nil, stdinErr
+        }
+        return io.NopCloser(bytes.NewReader(stdinData)), nil
+    }
+    if s.Path != "" {
+        return os.Open(s.Path)
+    }
+    if s.Name == "" {
+        return nil, fmt.Errorf("no input named for day %d", s.Day)
+    }
+    searched := make([]string, 0)
+    dirs := searchDirs
+    if dir := os.Getenv(InputDirEnv); dir != "" {
+        dirs = append([]string{dir}, dirs...)
+    }
+    for _, dir := range dirs {
+        candidate := filepath.Join(dir, s.dayDir(), s.Name)
+        if file, err := openIfExists(candidate); err != nil || file != nil {
+            return file, err
+        }
+        searched = append(searched, candidate)
+    }
+    if embedded != nil {
+        candidate := path.Join(s.dayDir(), s.Name)
+        file, err := embedded.Open(candidate)
+        if err == nil {
+            return file, nil
+        }
+        if ! errors.Is(err, fs.ErrNotExist) {
+            return nil, err
+        }
+        searched = append(searched, "embedded:" + candidate)
+    }
+    candidate := filepath.Join("days", s.dayDir(), s.Name)
+    if file, err := openIfExists(candidate); file != nil || err != nil {
+        return file, err
+    }
+    searched = append(searched, candidate)
+    return nil, fmt.Errorf("input %s not found (searched %s): %w", s, strings.Join(searched, ", "), fs.ErrNotExist)
+}
+
+// openIfExists opens the file, returning neither file nor error if it simply
+// isn't there so the search can move on.
+func openIfExists(name string) (io.ReadCloser, error) {
+    file, err := os.Open(name)
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    return file, nil
+}
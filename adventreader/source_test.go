@@ -0,0 +1,161 @@
+package adventreader
+
+import (
+    "errors"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "testing"
+    "testing/fstest"
+)
+
+// inputs sets up a working directory and leaves the search paths, embedded
+// inputs and stdin as they were once the test is over.
+func inputs(t *testing.T) string {
+    t.Helper()
+    root := t.TempDir()
+    wd, err := os.Getwd()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := os.Chdir(root); err != nil {
+        t.Fatal(err)
+    }
+    oldDirs, oldEmbedded, oldStdin := searchDirs, embedded, os.Stdin
+    t.Cleanup(func() {
+        os.Chdir(wd)
+        searchDirs, embedded, os.Stdin = oldDirs, oldEmbedded, oldStdin
+        stdinOnce, stdinData, stdinErr = sync.Once{}, nil, nil
+    })
+    searchDirs, embedded = nil, nil
+    stdinOnce, stdinData, stdinErr = sync.Once{}, nil, nil
+    return root
+}
+
+func write(t *testing.T, name, content string) {
+    t.Helper()
+    if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+        t.Fatal(err)
+    }
+    if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func read(t *testing.T, src Source) (string, error) {
+    t.Helper()
+    file, err := src.Open()
+    if err != nil {
+        return "", err
+    }
+    defer file.Close()
+    data, err := io.ReadAll(file)
+    return string(data), err
+}
+
+func TestSearchOrder(t *testing.T) {
+    // each place that has the input holds its own name, so we can tell which
+    // one was found
+    tests := []struct {
+        name string
+        has []string
+        want string
+    }{
+        {"everywhere", []string{"env", "cache", "embedded", "repo"}, "env"},
+        {"no env", []string{"cache", "embedded", "repo"}, "cache"},
+        {"only embedded and repo", []string{"embedded", "repo"}, "embedded"},
+        {"only repo", []string{"repo"}, "repo"},
+        {"env and repo", []string{"env", "repo"}, "env"},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            root := inputs(t)
+            t.Setenv(InputDirEnv, filepath.Join(root, "env"))
+            AddSearchDir(filepath.Join(root, "cache"))
+            mapFS := fstest.MapFS{}
+            Embed(mapFS)
+            for _, place := range test.has {
+                switch place {
+                case "embedded":
+                    mapFS["day07/input.txt"] = &fstest.MapFile{Data: []byte(place)}
+                case "repo":
+                    write(t, filepath.Join("days", "day07", "input.txt"), place)
+                default:
+                    write(t, filepath.Join(root, place, "day07", "input.txt"), place)
+                }
+            }
+            got, err := read(t, SourceFor(7, "input.txt"))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if got != test.want {
+                t.Errorf("found the input in %s, expected %s", got, test.want)
+            }
+        })
+    }
+}
+
+func TestNotFound(t *testing.T) {
+    root := inputs(t)
+    t.Setenv(InputDirEnv, filepath.Join(root, "env"))
+    AddSearchDir(filepath.Join(root, "cache"))
+    Embed(fstest.MapFS{"day05/input.txt": &fstest.MapFile{Data: []byte("wrong day")}})
+    _, err := read(t, SourceFor(7, "input.txt"))
+    if ! errors.Is(err, fs.ErrNotExist) {
+        t.Fatalf("expected a not found error, got %v", err)
+    }
+    for _, searched := range []string{
+        filepath.Join(root, "env", "day07", "input.txt"),
+        filepath.Join(root, "cache", "day07", "input.txt"),
+        "embedded:day07/input.txt",
+        filepath.Join("days", "day07", "input.txt"),
+    } {
+        if ! strings.Contains(err.Error(), searched) {
+            t.Errorf("error doesn't mention %s: %v", searched, err)
+        }
+    }
+    if ! strings.HasPrefix(err.Error(), "input day 7 input.txt not found (searched ") {
+        t.Errorf("unexpected error %v", err)
+    }
+}
+
+func TestPathsSkipTheSearch(t *testing.T) {
+    root := inputs(t)
+    t.Setenv(InputDirEnv, filepath.Join(root, "env"))
+    write(t, filepath.Join(root, "env", "day07", "input.txt"), "env")
+    write(t, filepath.Join(root, "mine.txt"), "mine")
+    src := SourceFor(7, filepath.Join(root, "mine.txt"))
+    if got, err := read(t, src); err != nil || got != "mine" {
+        t.Errorf("read %q, %v from %v", got, err, src)
+    }
+    if got, err := read(t, SourceFor(7, "./mine.txt")); err != nil || got != "mine" {
+        t.Errorf("read %q, %v from ./mine.txt", got, err)
+    }
+}
+
+func TestStdinReplayed(t *testing.T) {
+    root := inputs(t)
+    write(t, filepath.Join(root, "stdin.txt"), "1\n2\n3\n")
+    stdin, err := os.Open(filepath.Join(root, "stdin.txt"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer stdin.Close()
+    os.Stdin = stdin
+    src := SourceFor(7, "-")
+    for i := 0; i < 3; i++ {
+        got, err := read(t, src)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if got != "1\n2\n3\n" {
+            t.Errorf("open %d read %q from stdin", i + 1, got)
+        }
+    }
+    if src.String() != "stdin" {
+        t.Errorf("stdin is called %q", src)
+    }
+}
@@ -0,0 +1,128 @@
+package adventrunner
+
+import (
+    "fmt"
+    "runtime"
+    "runtime/metrics"
+    "sync"
+    "testing"
+    "time"
+    reader "advent2021/adventreader"
+)
+
+// Stats is what a part cost to run.
+type Stats struct {
+    Elapsed time.Duration
+    Allocs uint64     // heap objects allocated
+    AllocBytes uint64 // bytes allocated over the whole run
+    PeakHeap uint64   // highest live heap seen while running
+}
+
+func (s Stats) String() string {
+    return fmt.Sprintf("%v, %d allocs (%s), peak heap %s", s.Elapsed.Round(time.Microsecond), s.Allocs, bytes(s.AllocBytes), bytes(s.PeakHeap))
+}
+
+func bytes(n uint64) string {
+    const unit = 1024
+    if n < unit {
+        return fmt.Sprintf("%dB", n)
+    }
+    div, exp := uint64(unit), 0
+    for n / div >= unit {
+        div *= unit
+        exp++
+    }
+    return fmt.Sprintf("%.1f%ciB", float64(n) / float64(div), "KMGTPE"[exp])
+}
+
+// peakSampleInterval is how often the live heap is sampled while a part runs.
+// Peaks shorter than this can be missed, but the allocation totals are exact.
+const peakSampleInterval = time.Millisecond
+
+// heapMetric is the live heap, the same thing as MemStats.HeapAlloc. Unlike
+// runtime.ReadMemStats, reading it doesn't stop the world, so sampling it
+// doesn't slow down the part being timed.
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// Measure is Run, but also reports the time and memory the part took. The
+// allocation totals are read before and after, outside the timed run.
+func (d *Day) Measure(part int, src reader.Source) (int, Stats, error) {
+    var before, after runtime.MemStats
+    runtime.GC()
+    runtime.ReadMemStats(&before)
+
+    peak := before.HeapAlloc
+    done := make(chan struct{})
+    var wg sync.WaitGroup
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        ticker := time.NewTicker(peakSampleInterval)
+        defer ticker.Stop()
+        sample := []metrics.Sample{{Name: heapMetric}}
+        for {
+            select {
+            case <-done:
+                return
+            case <-ticker.C:
+                metrics.Read(sample)
+                if heap := sample[0].Value.Uint64(); heap > peak {
+                    peak = heap
+                }
+            }
+        }
+    }()
+
+    start := time.Now()
+    result, err := d.Run(part, src)
+    elapsed := time.Since(start)
+    close(done)
+    wg.Wait()
+
+    runtime.ReadMemStats(&after)
+    if after.HeapAlloc > peak {
+        peak = after.HeapAlloc
+    }
+    stats := Stats{
+        Elapsed: elapsed,
+        Allocs: after.Mallocs - before.Mallocs,
+        AllocBytes: after.TotalAlloc - before.TotalAlloc,
+        PeakHeap: peak,
+    }
+    return result, stats, err
+}
+
+// Benchmark is a generated benchmark for one part of a day.
+type Benchmark struct {
+    Name string
+    Day *Day
+    Part int
+    F func(b *testing.B)
+}
+
+// Benchmarks generates a Benchmark function for every part of the given days,
+// each solving the part against src's input b.N times. They're named the way
+// `go test -bench` names them so the output works with the usual tools.
+func Benchmarks(days []*Day, input string) []Benchmark {
+    benchmarks := make([]Benchmark, 0)
+    for _, day := range days {
+        for part := 1; part <= len(day.Parts); part++ {
+            day, part := day, part
+            src := reader.SourceFor(day.Number, input)
+            benchmarks = append(benchmarks, Benchmark{
+                Name: fmt.Sprintf("BenchmarkDay%02dPart%d", day.Number, part),
+                Day: day,
+                Part: part,
+                F: func(b *testing.B) {
+                    b.ReportAllocs()
+                    for i := 0; i < b.N; i++ {
+                        if _, err := day.Run(part, src); err != nil {
+                            b.Fatal(err)
+                        }
+                    }
+                },
+            })
+        }
+    }
+    return benchmarks
+}
@@ -0,0 +1,68 @@
+package adventrunner
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+)
+
+// Part solves one half of a day's puzzle from the given input. Anything it
+// logs should go to logs, which tags every line with the day, part and time
+// spent.
+type Part func(src reader.Source, logs *logger.Logger) (int, error)
+
+// Day is a puzzle registered with the runner along with its parts, in order.
+type Day struct {
+    Number int
+    Parts []Part
+    tools map[string]Tool
+}
+
+var registry = make(map[int]*Day)
+
+// ErrUnsolved is returned by parts that are registered but don't produce a
+// real answer yet, so they show up as unsolved instead of passing off a
+// placeholder number as the result.
+var ErrUnsolved = errors.New("part is not solved yet")
+
+// Register makes a day's parts available to the runner. Days call it from
+// their init() so that importing the package is enough to register it.
+func Register(number int, parts ...Part) {
+    if _, ok := registry[number]; ok {
+        panic(fmt.Sprintf("Day %d registered twice!", number))
+    }
+    registry[number] = &Day{Number: number, Parts: parts}
+}
+
+// Lookup returns the registered day with the given number.
+func Lookup(number int) (*Day, error) {
+    day, ok := registry[number]
+    if ! ok {
+        return nil, fmt.Errorf("day %d is not registered", number)
+    }
+    return day, nil
+}
+
+// Days returns every registered day, ordered by number.
+func Days() []*Day {
+    days := make([]*Day, 0, len(registry))
+    for _, day := range registry {
+        days = append(days, day)
+    }
+    sort.Slice(days, func(i, j int) bool { return days[i].Number < days[j].Number })
+    return days
+}
+
+// Run solves a single part (1-indexed) of the day against the given input.
+func (d *Day) Run(part int, src reader.Source) (int, error) {
+    if part < 1 || part > len(d.Parts) {
+        return 0, fmt.Errorf("day %d has no part %d", d.Number, part)
+    }
+    result, err := d.Parts[part - 1](src, logger.Logs.WithDay(d.Number, part))
+    if err != nil {
+        return 0, fmt.Errorf("day %d part %d: %w", d.Number, part, err)
+    }
+    return result, nil
+}
@@ -0,0 +1,70 @@
+package adventrunner
+
+import (
+    "fmt"
+    "io"
+    "os"
+    "sort"
+)
+
+// Tool is an extra command a day offers beyond solving its parts, like
+// exporting a picture of its answer. Run gets the arguments after the tool's
+// name and parses its own flags.
+type Tool struct {
+    Name string
+    Summary string
+    Run func(args []string) error
+}
+
+// RegisterTool adds a tool to an already registered day, so call it after
+// Register in the day's init().
+func RegisterTool(number int, tool Tool) {
+    day, ok := registry[number]
+    if ! ok {
+        panic(fmt.Sprintf("Tool %q registered for day %d before the day was", tool.Name, number))
+    }
+    if day.tools == nil {
+        day.tools = make(map[string]Tool)
+    }
+    if _, ok := day.tools[tool.Name]; ok {
+        panic(fmt.Sprintf("Tool %q registered twice for day %d!", tool.Name, number))
+    }
+    day.tools[tool.Name] = tool
+}
+
+// Tools returns the day's tools, ordered by name.
+func (d *Day) Tools() []Tool {
+    tools := make([]Tool, 0, len(d.tools))
+    for _, tool := range d.tools {
+        tools = append(tools, tool)
+    }
+    sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+    return tools
+}
+
+// Tool returns the day's tool with the given name.
+func (d *Day) Tool(name string) (Tool, error) {
+    tool, ok := d.tools[name]
+    if ! ok {
+        return Tool{}, fmt.Errorf("day %d has no tool %q", d.Number, name)
+    }
+    return tool, nil
+}
+
+// WriteOutput is for tools with an output file flag: it calls write with the
+// file called name, "-" being stdout, and closes it afterwards, returning the
+// first error from either.
+func WriteOutput(name string, write func(w io.Writer) error) error {
+    if name == "-" {
+        return write(os.Stdout)
+    }
+    out, err := os.Create(name)
+    if err != nil {
+        return err
+    }
+    if err := write(out); err != nil {
+        out.Close()
+        return err
+    }
+    return out.Close()
+}
@@ -0,0 +1,144 @@
+package adventrunner
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "strconv"
+    "strings"
+    reader "advent2021/adventreader"
+)
+
+// Inputs are the input files every day is checked against.
+var Inputs = []string{"test.txt", "input.txt"}
+
+// Check identifies one row of the regression table: a part run on an input.
+type Check struct {
+    Day, Part int
+    Input string
+}
+
+func (c Check) String() string {
+    return fmt.Sprintf("day %d part %d (%s)", c.Day, c.Part, c.Input)
+}
+
+// Answers maps checks to their recorded answers.
+type Answers map[Check]int
+
+// LoadAnswers reads an answers file. Each non-blank line that isn't a #
+// comment is "<day> <part> <input> <answer>", e.g. "14 2 test.txt 2188189693529".
+func LoadAnswers(path string) (Answers, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+    answers := make(Answers)
+    scanner := bufio.NewScanner(file)
+    for lineNo := 1; scanner.Scan(); lineNo++ {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" || strings.HasPrefix(line, "#") {
+            continue
+        }
+        fields := strings.Fields(line)
+        if len(fields) != 4 {
+            return nil, fmt.Errorf("%s:%d: expected \"<day> <part> <input> <answer>\", got %q", path, lineNo, line)
+        }
+        check := Check{Input: fields[2]}
+        numbers := []*int{&check.Day, &check.Part}
+        for i, number := range numbers {
+            if *number, err = strconv.Atoi(fields[i]); err != nil {
+                return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+            }
+        }
+        answer, err := strconv.Atoi(fields[3])
+        if err != nil {
+            return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+        }
+        if _, ok := answers[check]; ok {
+            return nil, fmt.Errorf("%s:%d: %v recorded twice", path, lineNo, check)
+        }
+        answers[check] = answer
+    }
+    return answers, scanner.Err()
+}
+
+// Status is how a check turned out.
+type Status int
+
+const (
+    Pass Status = iota
+    Fail
+    Unsolved   // the part returned ErrUnsolved
+    Unrecorded // the part ran but there's no answer to compare with
+    Skipped    // the input file doesn't exist and nothing is recorded for it
+)
+
+func (s Status) String() string {
+    return [...]string{"pass", "FAIL", "unsolved", "unrecorded", "skipped"}[s]
+}
+
+// Result is the outcome of one check. Err is set when the part failed.
+type Result struct {
+    Check
+    Status Status
+    Got, Want int
+    Err error
+}
+
+// Verify runs every part of the given days against each input and compares
+// the results with the recorded answers. A missing input is only skipped when
+// there's no answer recorded for it, so checks can't quietly stop running
+// because their inputs went missing.
+func Verify(days []*Day, inputs []string, answers Answers) []Result {
+    results := make([]Result, 0)
+    for _, day := range days {
+        for _, input := range inputs {
+            for part := 1; part <= len(day.Parts); part++ {
+                results = append(results, verify(day, Check{day.Number, part, input}, answers))
+            }
+        }
+    }
+    return results
+}
+
+func verify(day *Day, check Check, answers Answers) (result Result) {
+    result.Check = check
+    // a panicking day is a failed check, not the end of the run
+    defer func() {
+        if r := recover(); r != nil {
+            result.Status = Fail
+            result.Err = fmt.Errorf("%v panicked: %v", check, r)
+        }
+    }()
+    want, recorded := answers[check]
+    result.Want = want
+    got, err := day.Run(check.Part, reader.SourceFor(check.Day, check.Input))
+    result.Got = got
+    switch {
+    case errors.Is(err, fs.ErrNotExist):
+        result.Status = Skipped
+        if recorded {
+            result.Status = Fail
+            result.Err = err
+        }
+    case errors.Is(err, ErrUnsolved):
+        result.Status = Unsolved
+        if recorded {
+            result.Status = Fail
+            result.Err = err
+        }
+    case err != nil:
+        result.Status = Fail
+        result.Err = err
+    case ! recorded:
+        result.Status = Unrecorded
+    case got != want:
+        result.Status = Fail
+    default:
+        result.Status = Pass
+    }
+    return result
+}
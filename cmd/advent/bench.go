@@ -0,0 +1,197 @@
+package main
+
+import (
+    "bufio"
+    "errors"
+    "flag"
+    "fmt"
+    "io"
+    "os"
+    "strconv"
+    "strings"
+    "testing"
+    "text/tabwriter"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// benchLine is one benchmark as `go test -bench` prints it.
+type benchLine struct {
+    Name string
+    N int
+    NsPerOp, BytesPerOp, AllocsPerOp float64
+}
+
+func (l benchLine) String() string {
+    return fmt.Sprintf("%s\t%8d\t%12.0f ns/op\t%10.0f B/op\t%8.0f allocs/op", l.Name, l.N, l.NsPerOp, l.BytesPerOp, l.AllocsPerOp)
+}
+
+func benchLineFrom(name string, r testing.BenchmarkResult) benchLine {
+    return benchLine{
+        Name: name,
+        N: r.N,
+        NsPerOp: float64(r.NsPerOp()),
+        BytesPerOp: float64(r.AllocedBytesPerOp()),
+        AllocsPerOp: float64(r.AllocsPerOp()),
+    }
+}
+
+// readBaseline reads benchmark lines saved by `advent bench --save` (or
+// `go test -bench`), ignoring anything that isn't one.
+func readBaseline(path string) (map[string]benchLine, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+    baseline := make(map[string]benchLine)
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        fields := strings.Fields(scanner.Text())
+        if len(fields) < 2 || ! strings.HasPrefix(fields[0], "Benchmark") {
+            continue
+        }
+        line := benchLine{Name: fields[0]}
+        if line.N, err = strconv.Atoi(fields[1]); err != nil {
+            continue
+        }
+        // the rest are "<value> <unit>" pairs
+        for i := 2; i + 1 < len(fields); i += 2 {
+            value, err := strconv.ParseFloat(fields[i], 64)
+            if err != nil {
+                return nil, fmt.Errorf("%s: bad value %q for %s", path, fields[i], line.Name)
+            }
+            switch fields[i + 1] {
+            case "ns/op":
+                line.NsPerOp = value
+            case "B/op":
+                line.BytesPerOp = value
+            case "allocs/op":
+                line.AllocsPerOp = value
+            }
+        }
+        baseline[line.Name] = line
+    }
+    return baseline, scanner.Err()
+}
+
+func benchCommand(args []string) error {
+    fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    benchtime := fs.String("benchtime", "1s", "how long to run each benchmark, or Nx to run it N times")
+    save := fs.String("save", "", "write the results to this file, for use as a later --baseline")
+    baselinePath := fs.String("baseline", "", "compare against results saved earlier with --save")
+    threshold := fs.Float64("threshold", 10, "percentage slowdown or extra allocation that counts as a regression")
+    positional, err := parseArgs(fs, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) > 1 {
+        return fmt.Errorf("expected at most one day, got %d arguments", len(positional))
+    }
+    which := "all"
+    if len(positional) == 1 {
+        which = positional[0]
+    }
+    days, err := selectDays(which)
+    if err != nil {
+        return err
+    }
+    var baseline map[string]benchLine
+    if *baselinePath != "" {
+        if baseline, err = readBaseline(*baselinePath); err != nil {
+            return err
+        }
+    }
+    testing.Init()
+    if err := flag.Set("test.benchtime", *benchtime); err != nil {
+        return fmt.Errorf("bad --benchtime: %w", err)
+    }
+
+    lines := make([]benchLine, 0)
+    for _, bench := range runner.Benchmarks(days, *input) {
+        restore, err := quiet()
+        if err != nil {
+            return err
+        }
+        // one measured run first, so that missing inputs and unsolved parts
+        // are skipped rather than failing the benchmark
+        _, stats, err := bench.Day.Measure(bench.Part, reader.SourceFor(bench.Day.Number, *input))
+        var result testing.BenchmarkResult
+        if err == nil {
+            result = testing.Benchmark(bench.F)
+        }
+        restore()
+        switch {
+        case errors.Is(err, os.ErrNotExist), errors.Is(err, runner.ErrUnsolved):
+            logger.Logs.Warningf("Skipping %s: %v", bench.Name, err)
+            continue
+        case err != nil:
+            return err
+        }
+        line := benchLineFrom(bench.Name, result)
+        lines = append(lines, line)
+        fmt.Printf("%v\t%10d peak-heap-B\n", line, stats.PeakHeap)
+    }
+
+    if *save != "" {
+        if err := writeBaseline(*save, lines); err != nil {
+            return err
+        }
+    }
+    if baseline == nil {
+        return nil
+    }
+    return compareBaseline(os.Stdout, baseline, lines, *threshold)
+}
+
+func writeBaseline(path string, lines []benchLine) error {
+    file, err := os.Create(path)
+    if err != nil {
+        return err
+    }
+    for _, line := range lines {
+        fmt.Fprintln(file, line)
+    }
+    return file.Close()
+}
+
+// compareBaseline prints the change in time and allocations for every
+// benchmark that's in both runs and fails if any got worse by more than
+// threshold percent.
+func compareBaseline(w io.Writer, baseline map[string]benchLine, lines []benchLine, threshold float64) error {
+    delta := func(old, new float64) float64 {
+        if old == 0 {
+            if new == 0 {
+                return 0
+            }
+            return 100
+        }
+        return (new - old) / old * 100
+    }
+    regressions := 0
+    table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintln(w)
+    fmt.Fprintln(table, "NAME\tOLD NS/OP\tNEW NS/OP\tDELTA\tOLD ALLOCS/OP\tNEW ALLOCS/OP\tDELTA\t\t")
+    for _, line := range lines {
+        old, ok := baseline[line.Name]
+        if ! ok {
+            continue
+        }
+        timeDelta := delta(old.NsPerOp, line.NsPerOp)
+        allocDelta := delta(old.AllocsPerOp, line.AllocsPerOp)
+        verdict := ""
+        if timeDelta > threshold || allocDelta > threshold {
+            verdict = "REGRESSION"
+            regressions++
+        }
+        fmt.Fprintf(table, "%s\t%.0f\t%.0f\t%+.1f%%\t%.0f\t%.0f\t%+.1f%%\t%s\t\n",
+            line.Name, old.NsPerOp, line.NsPerOp, timeDelta, old.AllocsPerOp, line.AllocsPerOp, allocDelta, verdict)
+    }
+    table.Flush()
+    if regressions > 0 {
+        return fmt.Errorf("%d benchmarks regressed by more than %.0f%%", regressions, threshold)
+    }
+    return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "errors"
+    "strings"
+    "testing"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// BenchmarkDays runs the generated benchmark for every part on the puzzle
+// examples, e.g. go test -bench Days/Day15 ./cmd/advent
+func BenchmarkDays(b *testing.B) {
+    b.Setenv(reader.InputDirEnv, "../../days")
+    for _, bench := range runner.Benchmarks(runner.Days(), "test.txt") {
+        bench := bench
+        b.Run(strings.TrimPrefix(bench.Name, "Benchmark"), func(b *testing.B) {
+            restore, err := quiet()
+            if err != nil {
+                b.Fatal(err)
+            }
+            defer restore()
+            if _, err := bench.Day.Run(bench.Part, reader.SourceFor(bench.Day.Number, "test.txt")); errors.Is(err, runner.ErrUnsolved) {
+                b.Skip(err)
+            }
+            bench.F(b)
+        })
+    }
+}
@@ -0,0 +1,28 @@
+package main
+
+// Every day registers itself with the runner on import.
+import (
+    _ "advent2021/days/day01"
+    _ "advent2021/days/day02"
+    _ "advent2021/days/day03"
+    _ "advent2021/days/day04"
+    _ "advent2021/days/day05"
+    _ "advent2021/days/day06"
+    _ "advent2021/days/day07"
+    _ "advent2021/days/day08"
+    _ "advent2021/days/day09"
+    _ "advent2021/days/day10"
+    _ "advent2021/days/day11"
+    _ "advent2021/days/day12"
+    _ "advent2021/days/day13"
+    _ "advent2021/days/day14"
+    _ "advent2021/days/day15"
+    _ "advent2021/days/day16"
+    _ "advent2021/days/day17"
+    _ "advent2021/days/day18"
+    _ "advent2021/days/day19"
+    _ "advent2021/days/day20"
+    _ "advent2021/days/day21"
+    _ "advent2021/days/day22"
+    _ "advent2021/days/day23"
+)
@@ -0,0 +1,22 @@
+//go:build embedinputs
+
+package main
+
+import (
+    "embed"
+    "io/fs"
+    reader "advent2021/adventreader"
+)
+
+// Building with -tags embedinputs bakes cmd/advent/inputs/dayNN/*.txt into
+// the binary so it can run without the source tree or ADVENT_INPUT_DIR.
+//go:embed inputs
+var inputs embed.FS
+
+func init() {
+    sub, err := fs.Sub(inputs, "inputs")
+    if err != nil {
+        panic(err)
+    }
+    reader.Embed(sub)
+}
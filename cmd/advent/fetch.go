@@ -0,0 +1,48 @@
+package main
+
+import (
+    "flag"
+    "fmt"
+    client "advent2021/adventclient"
+    logger "advent2021/adventlogger"
+)
+
+func newClient(configPath string) (*client.Client, error) {
+    cfg, err := client.LoadConfig(configPath)
+    if err != nil {
+        return nil, err
+    }
+    return client.New(cfg)
+}
+
+func fetchCommand(args []string) error {
+    fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+    configPath := fs.String("config", "", "config file (default: the user config dir)")
+    positional, err := parseArgs(fs, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) != 1 {
+        return fmt.Errorf("expected exactly one day, got %d arguments", len(positional))
+    }
+    days, err := selectDays(positional[0])
+    if err != nil {
+        return err
+    }
+    c, err := newClient(*configPath)
+    if err != nil {
+        return err
+    }
+    for _, day := range days {
+        path, fetched, err := c.FetchInput(day.Number)
+        if err != nil {
+            return fmt.Errorf("day %d: %w", day.Number, err)
+        }
+        if fetched {
+            logger.Logs.Infof("Day %d input downloaded to %s", day.Number, path)
+        } else {
+            logger.Logs.Infof("Day %d input already cached at %s", day.Number, path)
+        }
+    }
+    return nil
+}
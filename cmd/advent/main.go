@@ -0,0 +1,217 @@
+package main
+
+import (
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "strconv"
+    "time"
+    client "advent2021/adventclient"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+const usage = `Usage: advent [--log LEVEL] [--log-format text|json] [--log-file FILE] <command> [arguments]
+
+Commands:
+  run <day|all> [--part N] [--input FILE]   solve one day (or every day)
+  list                                      show the registered days
+  tool <day> [name] [arguments]             run one of a day's extra tools (with
+                                            no name, list them)
+  fetch <day|all> [--config FILE]           download and cache puzzle inputs
+  submit <day> <part> [answer] [--input FILE] [--config FILE]
+                                            submit an answer (solving for it if
+                                            none is given) and record the verdict
+  verify [day|all] [--answers FILE] [--verbose]
+                                            check every part on test.txt and
+                                            input.txt against recorded answers
+  bench [day|all] [--input FILE] [--benchtime 1s] [--save FILE]
+        [--baseline FILE] [--threshold PCT]
+                                            benchmark every part, optionally
+                                            comparing with a saved baseline
+
+Inputs are named files (input.txt, test.txt) searched for in
+$ADVENT_INPUT_DIR/dayNN, the download cache, inputs embedded in the binary,
+then days/dayNN. A path (anything containing a slash) is read directly and
+"-" reads stdin.
+
+Logging defaults to info level, as text, on the terminal. --log (or
+$ADVENT_LOG) picks trace, debug, info, warning or error; --log-format (or
+$ADVENT_LOG_FORMAT) picks text or json; --log-file (or $ADVENT_LOG_FILE)
+sends the logs to a file instead.
+
+fetch and submit read the session cookie from the config file or
+$ADVENT_SESSION, and talk to $ADVENT_BASE_URL when it is set.
+`
+
+type command func(args []string) error
+
+var commands = map[string]command{
+    "run": runCommand,
+    "list": listCommand,
+    "fetch": fetchCommand,
+    "submit": submitCommand,
+    "verify": verifyCommand,
+    "bench": benchCommand,
+    "tool": toolCommand,
+}
+
+func main() {
+    // logging flags come before the command: advent --log trace run 14
+    global := flag.NewFlagSet("advent", flag.ContinueOnError)
+    global.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+    logCfg := logger.ConfigFromEnv()
+    global.StringVar(&logCfg.Level, "log", logCfg.Level, "log level")
+    global.StringVar(&logCfg.Format, "log-format", logCfg.Format, "log format")
+    global.StringVar(&logCfg.File, "log-file", logCfg.File, "log file")
+    if err := global.Parse(os.Args[1:]); err != nil {
+        os.Exit(2)
+    }
+    if logCfg != logger.ConfigFromEnv() {
+        logs, err := logger.New(logCfg)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        logger.Logs = logs
+    } else if logger.LogsErr != nil {
+        fmt.Fprintln(os.Stderr, logger.LogsErr)
+        os.Exit(2)
+    }
+
+    args := global.Args()
+    if len(args) < 1 {
+        fmt.Fprint(os.Stderr, usage)
+        os.Exit(2)
+    }
+    cmd, ok := commands[args[0]]
+    if ! ok {
+        fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", args[0], usage)
+        os.Exit(2)
+    }
+    // downloaded inputs are found without having to point ADVENT_INPUT_DIR at them
+    if cfg, err := client.LoadConfig(""); err == nil {
+        reader.AddSearchDir(cfg.CacheDir)
+    }
+    if err := cmd(args[1:]); err != nil {
+        logger.Logs.Errorf("%s: %v", args[0], err)
+        os.Exit(1)
+    }
+}
+
+// parseArgs parses flags that may appear before, after or between the
+// positional arguments (so `advent run 14 --part 2` works) and returns the
+// positionals.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+    positional := make([]string, 0)
+    for {
+        if err := fs.Parse(args); err != nil {
+            return nil, err
+        }
+        args = fs.Args()
+        if len(args) == 0 {
+            return positional, nil
+        }
+        positional = append(positional, args[0])
+        args = args[1:]
+    }
+}
+
+// selectDays resolves a day argument ("14" or "all") to registered days.
+func selectDays(arg string) ([]*runner.Day, error) {
+    if arg == "all" {
+        return runner.Days(), nil
+    }
+    number, err := strconv.Atoi(arg)
+    if err != nil {
+        return nil, fmt.Errorf("day must be a number or \"all\", got %q", arg)
+    }
+    day, err := runner.Lookup(number)
+    if err != nil {
+        return nil, err
+    }
+    return []*runner.Day{day}, nil
+}
+
+func runCommand(args []string) error {
+    fs := flag.NewFlagSet("run", flag.ContinueOnError)
+    part := fs.Int("part", 0, "part to solve; 0 solves every part")
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    positional, err := parseArgs(fs, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) != 1 {
+        return fmt.Errorf("expected exactly one day, got %d arguments", len(positional))
+    }
+    days, err := selectDays(positional[0])
+    if err != nil {
+        return err
+    }
+    for _, day := range days {
+        parts := []int{*part}
+        if *part == 0 {
+            parts = make([]int, 0)
+            for i := range day.Parts {
+                parts = append(parts, i + 1)
+            }
+        }
+        for _, p := range parts {
+            result, stats, err := day.Measure(p, reader.SourceFor(day.Number, *input))
+            if errors.Is(err, runner.ErrUnsolved) {
+                logger.Logs.Warningf("Day %d part %d is not solved yet", day.Number, p)
+                continue
+            }
+            if err != nil {
+                return err
+            }
+            fmt.Printf("Day %d part %d: %d (%v)\n", day.Number, p, result, stats)
+            logger.Logs.WithFields(logger.Fields{
+                "day": day.Number,
+                "part": p,
+                "result": result,
+                "elapsed": stats.Elapsed.Round(time.Microsecond).String(),
+                "allocs": stats.Allocs,
+                "peak_heap": stats.PeakHeap,
+            }).Tracef("Day %d part %d result: %d (%v)", day.Number, p, result, stats)
+        }
+    }
+    return nil
+}
+
+func listCommand(args []string) error {
+    fs := flag.NewFlagSet("list", flag.ContinueOnError)
+    if _, err := parseArgs(fs, args); err != nil {
+        return err
+    }
+    for _, day := range runner.Days() {
+        tools := make([]string, 0)
+        for _, tool := range day.Tools() {
+            tools = append(tools, tool.Name)
+        }
+        fmt.Printf("Day %2d: %d parts%s\n", day.Number, len(day.Parts), toolNames(tools))
+    }
+    return nil
+}
+
+// quiet sends stdout and the info and warning logs to /dev/null, since plenty
+// of days draw their boards and log as they go, which buries anything we want
+// to print about them. Call restore to put things back.
+func quiet() (restore func(), err error) {
+    devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+    if err != nil {
+        return nil, err
+    }
+    stdout, info, warning := os.Stdout, logger.Logs.Info.Out, logger.Logs.Warning.Out
+    os.Stdout = devNull
+    logger.Logs.Info.SetOutput(devNull)
+    logger.Logs.Warning.SetOutput(devNull)
+    return func() {
+        os.Stdout = stdout
+        logger.Logs.Info.SetOutput(info)
+        logger.Logs.Warning.SetOutput(warning)
+        devNull.Close()
+    }, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+    "errors"
+    "flag"
+    "fmt"
+    "strconv"
+    client "advent2021/adventclient"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+func submitCommand(args []string) error {
+    fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+    configPath := fs.String("config", "", "config file (default: the user config dir)")
+    input := fs.String("input", "input.txt", "input to solve when no answer is given")
+    positional, err := parseArgs(fs, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) < 2 || len(positional) > 3 {
+        return fmt.Errorf("usage: advent submit <day> <part> [answer]")
+    }
+    number, err := strconv.Atoi(positional[0])
+    if err != nil {
+        return fmt.Errorf("day must be a number, got %q", positional[0])
+    }
+    part, err := strconv.Atoi(positional[1])
+    if err != nil {
+        return fmt.Errorf("part must be a number, got %q", positional[1])
+    }
+    day, err := runner.Lookup(number)
+    if err != nil {
+        return err
+    }
+    var answer string
+    if len(positional) == 3 {
+        answer = positional[2]
+    } else {
+        result, err := day.Run(part, reader.SourceFor(number, *input))
+        if errors.Is(err, runner.ErrUnsolved) {
+            return fmt.Errorf("day %d part %d doesn't produce an answer, so give it on the command line", number, part)
+        }
+        if err != nil {
+            return err
+        }
+        answer = strconv.Itoa(result)
+    }
+
+    c, err := newClient(*configPath)
+    if err != nil {
+        return err
+    }
+    answers, err := client.LoadAnswers(c.AnswersPath())
+    if err != nil {
+        return err
+    }
+    if err := answers.Check(number, part, answer); err != nil {
+        return fmt.Errorf("not submitting day %d part %d: %w", number, part, err)
+    }
+    resp, err := c.Submit(number, part, answer)
+    if err != nil {
+        return err
+    }
+    answers.Record(number, part, answer, resp.Verdict)
+    if err := answers.Save(); err != nil {
+        return err
+    }
+    switch resp.Verdict {
+    case client.Correct:
+        logger.Logs.Infof("Day %d part %d: %s is correct!", number, part, answer)
+    case client.TooSoon:
+        logger.Logs.Warningf("Day %d part %d: answered too recently, wait %s", number, part, resp.Wait)
+    case client.Unknown:
+        logger.Logs.Warningf("Day %d part %d: couldn't make sense of the response: %s", number, part, resp.Message)
+    default:
+        logger.Logs.Warningf("Day %d part %d: %s is %s", number, part, answer, resp.Verdict)
+    }
+    return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+    "fmt"
+    "strings"
+)
+
+func toolCommand(args []string) error {
+    if len(args) < 1 {
+        return fmt.Errorf("expected a day, and the tool to run")
+    }
+    days, err := selectDays(args[0])
+    if err != nil {
+        return err
+    }
+    if len(days) != 1 {
+        return fmt.Errorf("tools belong to a single day")
+    }
+    day := days[0]
+    if len(args) < 2 {
+        tools := day.Tools()
+        if len(tools) == 0 {
+            fmt.Printf("Day %d has no tools\n", day.Number)
+        }
+        for _, tool := range tools {
+            fmt.Printf("%-12s %s\n", tool.Name, tool.Summary)
+        }
+        return nil
+    }
+    tool, err := day.Tool(args[1])
+    if err != nil {
+        return err
+    }
+    return tool.Run(args[2:])
+}
+
+// toolNames lists a day's tools for `advent list`.
+func toolNames(tools []string) string {
+    if len(tools) == 0 {
+        return ""
+    }
+    return ", tools: " + strings.Join(tools, " ")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "text/tabwriter"
+    runner "advent2021/adventrunner"
+)
+
+func verifyCommand(args []string) error {
+    fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+    answersPath := fs.String("answers", "answers.txt", "file of recorded answers")
+    verbose := fs.Bool("verbose", false, "let days print their boards and progress while they run")
+    positional, err := parseArgs(fs, args)
+    if err != nil {
+        return err
+    }
+    if len(positional) > 1 {
+        return fmt.Errorf("expected at most one day, got %d arguments", len(positional))
+    }
+    which := "all"
+    if len(positional) == 1 {
+        which = positional[0]
+    }
+    days, err := selectDays(which)
+    if err != nil {
+        return err
+    }
+    answers, err := runner.LoadAnswers(*answersPath)
+    if err != nil {
+        return err
+    }
+
+    restore := func() {}
+    if ! *verbose {
+        if restore, err = quiet(); err != nil {
+            return err
+        }
+    }
+    results := runner.Verify(days, runner.Inputs, answers)
+    restore()
+
+    counts := make(map[runner.Status]int)
+    table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(table, "DAY\tPART\tINPUT\tSTATUS\tGOT\tWANT")
+    for _, result := range results {
+        counts[result.Status]++
+        if result.Status == runner.Skipped {
+            continue
+        }
+        got, want := fmt.Sprint(result.Got), fmt.Sprint(result.Want)
+        switch result.Status {
+        case runner.Unsolved:
+            got, want = "-", "-"
+        case runner.Unrecorded:
+            want = "-"
+        }
+        if result.Err != nil {
+            got = result.Err.Error()
+        }
+        fmt.Fprintf(table, "%d\t%d\t%s\t%s\t%s\t%s\n", result.Day, result.Part, result.Input, result.Status, got, want)
+    }
+    table.Flush()
+    fmt.Printf("\n%d passed, %d failed, %d unsolved, %d unrecorded, %d skipped (no input)\n",
+        counts[runner.Pass], counts[runner.Fail], counts[runner.Unsolved], counts[runner.Unrecorded], counts[runner.Skipped])
+    if counts[runner.Fail] > 0 {
+        return fmt.Errorf("%d checks failed", counts[runner.Fail])
+    }
+    return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+    "testing"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// TestExamples checks every day against the answers recorded for the puzzle
+// examples in days/dayNN/test.txt.
+func TestExamples(t *testing.T) {
+    t.Setenv(reader.InputDirEnv, "../../days")
+    answers, err := runner.LoadAnswers("../../answers.txt")
+    if err != nil {
+        t.Fatal(err)
+    }
+    restore, err := quiet()
+    if err != nil {
+        t.Fatal(err)
+    }
+    results := runner.Verify(runner.Days(), []string{"test.txt"}, answers)
+    restore()
+
+    passed, recorded := 0, 0
+    for check := range answers {
+        if check.Input == "test.txt" {
+            recorded++
+        }
+    }
+    for _, result := range results {
+        switch result.Status {
+        case runner.Pass:
+            passed++
+        case runner.Fail:
+            t.Errorf("%v: got %d, want %d (%v)", result.Check, result.Got, result.Want, result.Err)
+        }
+    }
+    if passed != recorded {
+        t.Errorf("%d of %d recorded answers passed", passed, recorded)
+    }
+}
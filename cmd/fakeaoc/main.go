@@ -0,0 +1,90 @@
+// fakeaoc runs a local stand-in for the Advent of Code site. Point the advent
+// binary at it with ADVENT_BASE_URL=http://localhost:8080.
+package main
+
+import (
+    "flag"
+    "fmt"
+    "net/http"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+    logger "advent2021/adventlogger"
+    "advent2021/adventclient/fakeaoc"
+)
+
+func main() {
+    addr := flag.String("addr", "localhost:8080", "address to listen on")
+    session := flag.String("session", "fake-session", "session cookie to accept")
+    inputs := flag.String("inputs", "", "directory of dayNN/input.txt files to serve")
+    answers := flag.String("answers", "", "file of \"day part answer\" lines to judge submissions against")
+    cooldown := flag.Duration("cooldown", time.Minute, "how long to refuse answers after each one")
+    flag.Parse()
+
+    server := fakeaoc.New(*session)
+    server.Cooldown = *cooldown
+    if *inputs != "" {
+        if err := loadInputs(server, *inputs); err != nil {
+            logger.Logs.Errorf("%v", err)
+            os.Exit(1)
+        }
+    }
+    if *answers != "" {
+        if err := loadAnswers(server, *answers); err != nil {
+            logger.Logs.Errorf("%v", err)
+            os.Exit(1)
+        }
+    }
+    logger.Logs.Infof("Serving %d inputs and %d answers on http://%s", len(server.Inputs), len(server.Answers), *addr)
+    if err := http.ListenAndServe(*addr, server); err != nil {
+        logger.Logs.Errorf("%v", err)
+        os.Exit(1)
+    }
+}
+
+func loadInputs(server *fakeaoc.Server, dir string) error {
+    paths, err := filepath.Glob(filepath.Join(dir, "day*", "input.txt"))
+    if err != nil {
+        return err
+    }
+    dayReg := regexp.MustCompile(`day(\d+)$`)
+    for _, path := range paths {
+        match := dayReg.FindStringSubmatch(filepath.Dir(path))
+        if match == nil {
+            continue
+        }
+        day, _ := strconv.Atoi(match[1])
+        data, err := os.ReadFile(path)
+        if err != nil {
+            return fmt.Errorf("reading %s: %w", path, err)
+        }
+        server.Inputs[day] = string(data)
+    }
+    return nil
+}
+
+func loadAnswers(server *fakeaoc.Server, path string) error {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    for i, line := range strings.Split(string(data), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+            continue
+        }
+        if len(fields) != 3 {
+            return fmt.Errorf("%s:%d: expected \"day part answer\"", path, i + 1)
+        }
+        day, dayErr := strconv.Atoi(fields[0])
+        part, partErr := strconv.Atoi(fields[1])
+        if dayErr != nil || partErr != nil {
+            return fmt.Errorf("%s:%d: day and part must be numbers", path, i + 1)
+        }
+        server.Answers[fakeaoc.Part{Day: day, Part: part}] = fields[2]
+    }
+    return nil
+}
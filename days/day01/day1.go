@@ -1,31 +1,28 @@
-package main
+package day01
 
 import (
-    "bufio"
-    "bytes"
     "strconv"
     logger "advent2021/adventlogger"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
+func init() {
+    runner.Register(1, part1, part2)
 }
 
-func part1() int {
-    input := bytes.NewBuffer(reader.FromFile("input.txt"))
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
     start, prev, count := false, 0, 0
-    scanner := bufio.NewScanner(input)
-    for scanner.Scan() {
-        entry, _ := strconv.Atoi(scanner.Text())
+    err := reader.EachLine(src, func(line string) error {
+        entry, err := strconv.Atoi(line)
+        if err != nil {
+            return err
+        }
         if ! start {
             // logger.Logs.Infof("Started: %t. Setting prev to %d", start, entry)
             prev = entry
             start = true
-            continue
+            return nil
         }
         // logger.Logs.Infof("Comparing entry (%d) -ge prev (%d)", intEntry, intPrev)
         if entry > prev {
@@ -35,17 +32,23 @@ func part1() int {
         }
         // logger.Logs.Infof("Updating prev (%d) to entry (%d)", prev, entry)
         prev = entry
-    }
-    return count
+        return nil
+    })
+    return count, err
 }
 
-func part2() int {
-    input := bytes.NewBuffer(reader.FromFile("input.txt"))
-    scanner := bufio.NewScanner(input)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
     var entries []int
-    for scanner.Scan() {
-        entry, _ := strconv.Atoi(scanner.Text())
+    err := reader.EachLine(src, func(line string) error {
+        entry, err := strconv.Atoi(line)
+        if err != nil {
+            return err
+        }
         entries = append(entries, entry)
+        return nil
+    })
+    if err != nil {
+        return 0, err
     }
     start, prev, count := false, 0, 0
     for i := 0; i < len(entries) - 2; i++ {
@@ -65,5 +68,5 @@ func part2() int {
         // logger.Logs.Infof("Updating prev (%d) to entry (%d)", prev, entry)
         prev = entry
     }
-    return count
+    return count, nil
 }
@@ -0,0 +1,63 @@
+package day02
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+func init() {
+    runner.Register(2, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    x_pos, y_pos := 0, 0
+    err := reader.EachLine(src, func(line string) error {
+        tokens := strings.Split(line, " ")
+        if len(tokens) != 2 {
+            return fmt.Errorf("expected a direction and distance, got %q", line)
+        }
+        unit, err := strconv.Atoi(tokens[1])
+        if err != nil {
+            return err
+        }
+        switch direction := tokens[0]; direction {
+        case "forward":
+            x_pos += unit
+        case "down":
+            y_pos += unit
+        case "up":
+            y_pos -= unit
+        }
+        return nil
+    })
+    return x_pos * y_pos, err
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    x_pos, y_pos, aim := 0, 0, 0
+    err := reader.EachLine(src, func(line string) error {
+        tokens := strings.Split(line, " ")
+        if len(tokens) != 2 {
+            return fmt.Errorf("expected a direction and distance, got %q", line)
+        }
+        unit, err := strconv.Atoi(tokens[1])
+        if err != nil {
+            return err
+        }
+        switch direction := tokens[0]; direction {
+        case "forward":
+            x_pos += unit
+            y_pos += (aim * unit)
+        case "down":
+            aim += unit
+        case "up":
+            aim -= unit
+        }
+        return nil
+    })
+    return x_pos * y_pos, err
+}
@@ -1,19 +1,15 @@
-package main
+package day03
 
 import (
     "fmt"
     "strconv"
+    "strings"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
 type commonBinary struct {
     lines []string
     zeroes []int
@@ -98,19 +94,71 @@ func (cb *commonBinary) UncommonAtIndex(index int) string {
     }
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
+func init() {
+    runner.Register(3, part1, part2)
+}
+
+// readReport reads the diagnostic report, which must be lines of 0s and 1s,
+// all the same length.
+func readReport(src reader.Source) ([]string, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return nil, err
+    }
+    if len(lines) == 0 {
+        return nil, fmt.Errorf("the report is empty")
+    }
+    for i, line := range lines {
+        if len(line) != len(lines[0]) {
+            return nil, &parser.LineError{Line: i + 1, Err: fmt.Errorf("%d bits long, expected %d", len(line), len(lines[0]))}
+        }
+        if strings.Trim(line, "01") != "" {
+            return nil, &parser.LineError{Line: i + 1, Err: fmt.Errorf("%q isn't binary", line)}
+        }
+    }
+    return lines, nil
+}
+
+// rating parses one of the report's lines, saying which line it was if it
+// has too many bits to fit.
+func rating(lines []string, line string) (int, error) {
+    n, err := strconv.ParseInt(line, 2, 64)
+    if err != nil {
+        for i := range lines {
+            if lines[i] == line {
+                return 0, &parser.LineError{Line: i + 1, Err: err}
+            }
+        }
+        return 0, err
+    }
+    return int(n), nil
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := readReport(src)
+    if err != nil {
+        return 0, err
+    }
     cb := commonBinary{lines: lines}
     cb.CountCommon()
     more, less := cb.Commonality()
-    logger.Logs.Infof("Gamma rate: %s, epsilon rate: %s", more, less)
-    moreInt, _ := strconv.ParseInt(more, 2, 64)
-    lessInt, _ := strconv.ParseInt(less, 2, 64)
-    return int(moreInt) * int(lessInt)
+    logs.Infof("Gamma rate: %s, epsilon rate: %s", more, less)
+    moreInt, err := strconv.ParseInt(more, 2, 64)
+    if err != nil {
+        return 0, fmt.Errorf("gamma rate: %w", err)
+    }
+    lessInt, err := strconv.ParseInt(less, 2, 64)
+    if err != nil {
+        return 0, fmt.Errorf("epsilon rate: %w", err)
+    }
+    return int(moreInt) * int(lessInt), nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := readReport(src)
+    if err != nil {
+        return 0, err
+    }
 
     // Get O2 rating
     cb := commonBinary{lines: lines}
@@ -120,7 +168,10 @@ func part2() int {
         index += 1
         cb.Reduce(index, false)
     }
-    o2RatingInt, _ := strconv.ParseInt(cb.lines[0], 2, 64)
+    o2RatingInt, err := rating(lines, cb.lines[0])
+    if err != nil {
+        return 0, err
+    }
 
     // Get CO2 rating
     index = 0
@@ -130,9 +181,12 @@ func part2() int {
         index += 1
         cb.Reduce(index, true)
     }
-    co2RatingInt, _ := strconv.ParseInt(cb.lines[0], 2, 64)
+    co2RatingInt, err := rating(lines, cb.lines[0])
+    if err != nil {
+        return 0, err
+    }
 
     // Multiply result
-    logger.Logs.Infof("O2 Rating: %d, CO2 Rating: %d", o2RatingInt, co2RatingInt)
-    return int(o2RatingInt) * int(co2RatingInt)
+    logs.Infof("O2 Rating: %d, CO2 Rating: %d", o2RatingInt, co2RatingInt)
+    return o2RatingInt * co2RatingInt, nil
 }
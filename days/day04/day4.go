@@ -1,23 +1,16 @@
-package main
+package day04
 
 import (
     "fmt"
-    "regexp"
-    "strconv"
     "strings"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 const BingoLen = 5
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
 type Point struct {
     value int
     marked bool
@@ -115,42 +108,47 @@ func (b *Board) SumEmpties() int {
 }
 
 
-func stringsToInts (numbersString string, separator string) []int {
-    var numbers []int
-    numberStrings := regexp.MustCompile(separator).Split(strings.TrimSpace(numbersString), -1)
-    for _, numberString := range numberStrings {
-        number, _ := strconv.Atoi(strings.TrimSpace(numberString))
-        numbers = append(numbers, number)
+func gameFromInput(lines []string) ([]int, []Board, error) {
+    blocks := parser.Blocks(lines)
+    if len(blocks) == 0 {
+        return nil, nil, fmt.Errorf("no bingo numbers to call")
+    }
+    bingoCalls, err := parser.IntLines(blocks[0].Lines, ",")
+    if err != nil {
+        return nil, nil, blocks[0].Err(err)
     }
-    return numbers
-}
-
-func gameFromInput(lines []string) ([]int, []Board) {
-    bingoCalls := stringsToInts(lines[0], ",")
-    lines = lines[1:]
     var boards []Board
-    board := Board{make([][]*Point, 0), make(map[int]*Point)}
-    count := 0
-    for i := 0; i < len(lines); i++ {
-        if lines[i] == "" {
-            continue
-        } else {
-            board.AddRow(stringsToInts(lines[i], `\s+`))
-            count += 1
+    for _, block := range blocks[1:] {
+        rows, err := parser.IntRows(block.Lines, `\s+`)
+        if err != nil {
+            return nil, nil, block.Err(err)
+        }
+        if len(rows) != BingoLen {
+            return nil, nil, &parser.LineError{Line: block.Line, Err: fmt.Errorf("board has %d rows, expected %d", len(rows), BingoLen)}
         }
-        if count == BingoLen {
-            boards = append(boards, board)
-            board = Board{make([][]*Point, 0), make(map[int]*Point)}
-            count = 0
+        board := Board{make([][]*Point, 0), make(map[int]*Point)}
+        for _, row := range rows {
+            board.AddRow(row)
         }
-    }   
-    return bingoCalls, boards
+        boards = append(boards, board)
+    }
+    return bingoCalls, boards, nil
 }
 
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
-    bingoCalls, boards := gameFromInput(lines)
+func init() {
+    runner.Register(4, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    bingoCalls, boards, err := gameFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
     // logger.Logs.Infof("Bingo numbers to call: %d", bingoCalls)
     // logger.Logs.Infof("%d Boards to play", len(boards))
     // for i := range boards {
@@ -161,17 +159,23 @@ func part1() int {
             board.MarkPoint(number)
             if board.CheckRun() {
                 emptySum := board.SumEmpties()
-                return emptySum * number
+                return emptySum * number, nil
             }
         }
     }
-    logger.Logs.Infof("You screwed up!")
-    return 4
+    logs.Infof("You screwed up!")
+    return 4, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    bingoCalls, boards := gameFromInput(lines)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    bingoCalls, boards, err := gameFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
     // logger.Logs.Infof("Bingo numbers to call: %d", bingoCalls)
     // logger.Logs.Infof("%d Boards to play", len(boards))
     // for i := range boards {
@@ -192,5 +196,5 @@ func part2() int {
             }
         }
     }
-    return lastScore
+    return lastScore, nil
 }
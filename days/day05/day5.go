@@ -1,31 +1,16 @@
-package main
+package day05
 
 import (
-    "fmt"
     "regexp"
-    "strconv"
-    "strings"
+    grid "advent2021/adventgrid"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
-type Point struct {
-    x, y int
-}
-
-func (p Point) String() string {
-    return fmt.Sprintf("%d,%d", p.x, p.y)
-}
-
 type Board struct {
-    heights map[Point]int
+    heights *grid.Sparse[int]
     maxHeight int
     numMaxHeight map[int]int
 }
@@ -64,22 +49,22 @@ func slope(x1, y1, x2, y2 int) (int, int) {
 }
 
 func newBoard() *Board {
-    heights := make(map[Point]int)
+    heights := grid.NewSparse[int]()
     numMaxHeight := make(map[int]int)
     board := Board{heights: heights, maxHeight: 0, numMaxHeight: numMaxHeight}
     return &board
 }
 
-func (b *Board) trackMaxHeight(point Point) {
-    height := b.heights[point]
+func (b *Board) trackMaxHeight(point grid.Point) {
+    height := b.heights.Get(point)
     if height >= b.maxHeight {
         b.maxHeight = height
         b.numMaxHeight[height] += 1
     }
 }
 
-func (b *Board) AddHorizVertLine(p1, p2 Point) {
-    if p1.x == p2.x || p1.y == p2.y {
+func (b *Board) AddHorizVertLine(p1, p2 grid.Point) {
+    if p1.X == p2.X || p1.Y == p2.Y {
         // logger.Logs.Infof("Required horiz|vert line only; satisfied by points %s -> %s", p1, p2)
         b.AddLine(p1, p2)
         return
@@ -87,10 +72,10 @@ func (b *Board) AddHorizVertLine(p1, p2 Point) {
     // logger.Logs.Infof("Required horiz|vert line only; NOT SATISFIED by points %s -> %s", p1, p2)
 }
 
-func (b *Board) AddHVDLine(p1, p2 Point) {
+func (b *Board) AddHVDLine(p1, p2 grid.Point) {
     // Add lines if they are horizontal, vertical, or pi/4 radians.
-    dx, dy := slope(p1.x, p1.y, p2.x, p2.y)
-    if p1.x == p2.x || p1.y == p2.y || dx == dy || dx + dy == 0 {
+    dx, dy := slope(p1.X, p1.Y, p2.X, p2.Y)
+    if p1.X == p2.X || p1.Y == p2.Y || dx == dy || dx + dy == 0 {
         // logger.Logs.Infof("Required horiz|vert line only; satisfied by points %s -> %s", p1, p2)
         b.AddLine(p1, p2)
         return
@@ -98,41 +83,39 @@ func (b *Board) AddHVDLine(p1, p2 Point) {
     // logger.Logs.Infof("Required horiz|vert line only; NOT SATISFIED by points %s -> %s", p1, p2)
 }
 
-func (b *Board) AddLine(p1, p2 Point) {
-    dx, dy := slope(p1.x, p1.y, p2.x, p2.y)
-    i, j := p1.x, p1.y
-    for i != p2.x || j != p2.y {
-        point := Point{i, j}
+func (b *Board) AddLine(p1, p2 grid.Point) {
+    dx, dy := slope(p1.X, p1.Y, p2.X, p2.Y)
+    i, j := p1.X, p1.Y
+    for i != p2.X || j != p2.Y {
+        point := grid.Point{X: i, Y: j}
         //logger.Logs.Infof("Tracking point %s on line connecting %s to %s", point, p1, p2)
-        b.heights[point] += 1
+        b.heights.Set(point, b.heights.Get(point) + 1)
         b.trackMaxHeight(point)
         i += dx
         j += dy
     }
     // Account for the final point
-    point := Point{i, j}
+    point := grid.Point{X: i, Y: j}
     // logger.Logs.Infof("Tracking final point %s on line connecting %s to %s", point, p1, p2)
-    b.heights[point] += 1
+    b.heights.Set(point, b.heights.Get(point) + 1)
     b.trackMaxHeight(point)
 }
 
-func lineToPoints (lineString string, separator string) []Point {
-    tokens := regexp.MustCompile(separator).Split(strings.TrimSpace(lineString), -1)
-    points := make([]Point, 0)
-    for _, coordString := range tokens {
-        xyStringList := regexp.MustCompile(",").Split(coordString, -1)
-        x, _ := strconv.Atoi(xyStringList[0])
-        y, _ := strconv.Atoi(xyStringList[1])
-        points = append(points, Point{x, y})
-    }
-    return points
+var segmentReg = regexp.MustCompile(`^(?P<x1>\d+),(?P<y1>\d+)\s->\s(?P<x2>\d+),(?P<y2>\d+)$`)
+
+type segment struct {
+    X1, Y1, X2, Y2 int
 }
 
-func boardFromInput(lines []string, choice ...string) *Board {
+func boardFromInput(lines []string, choice ...string) (*Board, error) {
+    segments := make([]segment, 0)
+    if err := parser.MatchLines(segmentReg, lines, &segments); err != nil {
+        return nil, err
+    }
     board := newBoard()
-    for _, line := range lines {
-        // logger.Logs.Infof("Adding line to board; line = %s", line)
-        points := lineToPoints(line, `\s->\s`)
+    for _, seg := range segments {
+        // logger.Logs.Infof("Adding segment to board; segment = %v", seg)
+        points := []grid.Point{{X: seg.X1, Y: seg.Y1}, {X: seg.X2, Y: seg.Y2}}
         if len(choice) > 0 {
             switch linesTypes := choice[0]; linesTypes {
             case "hv": 
@@ -144,31 +127,44 @@ func boardFromInput(lines []string, choice ...string) *Board {
             board.AddLine(points[0], points[1])
         }
     }
-    return board
+    return board, nil
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
-    board := boardFromInput(lines, "hv")
+// overlaps counts the points where at least two lines cross
+func (b *Board) overlaps() int {
     sum := 0
-    for _, height := range board.heights {
-        if height < 2 {
-            continue
+    b.heights.Each(func(_ grid.Point, height int) {
+        if height >= 2 {
+            sum += 1
         }
-        sum += 1
-    }
+    })
     return sum
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    board := boardFromInput(lines, "hvd")
-    sum := 0
-    for _, height := range board.heights {
-        if height < 2 {
-            continue
-        }
-        sum += 1
+func init() {
+    runner.Register(5, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
     }
-    return sum
+    board, err := boardFromInput(lines, "hv")
+    if err != nil {
+        return 0, err
+    }
+    return board.overlaps(), nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines, "hvd")
+    if err != nil {
+        return 0, err
+    }
+    return board.overlaps(), nil
 }
@@ -1,23 +1,16 @@
-package main
+package day06
 
 import (
-    "regexp"
-    "strconv"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 const DaysToAnalyze = 80
 const AnglerCycle = 8
 const AnglerRefractory = 2
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
 func anglersTick(anglers map[int]int) map[int]int {
     replace := make(map[int]int)
     for dayNum := 0; dayNum < AnglerCycle + 1; dayNum++ {
@@ -34,22 +27,32 @@ func anglersTick(anglers map[int]int) map[int]int {
     return replace
 }
 
-func makeAnglersFromLines(lines []string) map[int]int {
+func makeAnglersFromLines(lines []string) (map[int]int, error) {
+    timers, err := parser.IntLines(lines, ",")
+    if err != nil {
+        return nil, err
+    }
     anglers := make(map[int]int)
-    for _, line := range lines {
-        intList := regexp.MustCompile(",").Split(line, -1)
-        for _, intString := range intList {
-            integer, _ := strconv.Atoi(intString)
-            anglers[integer] += 1
-        }
+    for _, timer := range timers {
+        anglers[timer] += 1
     }
-    return anglers
+    return anglers, nil
+}
+
+func init() {
+    runner.Register(6, part1, part2)
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
-    anglers := makeAnglersFromLines(lines)
-    logger.Logs.Infof("Anglers: %d", anglers)
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    anglers, err := makeAnglersFromLines(lines)
+    if err != nil {
+        return 0, err
+    }
+    logs.Infof("Anglers: %d", anglers)
     for i := 0; i < DaysToAnalyze; i++ {
         anglers = anglersTick(anglers)
         // logger.Logs.Infof("Anglers after day %d: %d", i + 1, anglers)
@@ -58,13 +61,19 @@ func part1() int {
     for _, numAnglers := range anglers {
         sum += numAnglers
     }
-    return sum
+    return sum, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    anglers := makeAnglersFromLines(lines)
-    logger.Logs.Infof("Anglers: %d", anglers)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    anglers, err := makeAnglersFromLines(lines)
+    if err != nil {
+        return 0, err
+    }
+    logs.Infof("Anglers: %d", anglers)
     for i := 0; i < 256; i++ {
         anglers = anglersTick(anglers)
         // logger.Logs.Infof("Anglers after day %d: %d", i + 1, anglers)
@@ -73,6 +82,6 @@ func part2() int {
     for _, numAnglers := range anglers {
         sum += numAnglers
     }
-    return sum
+    return sum, nil
 }
 
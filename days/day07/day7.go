@@ -1,33 +1,14 @@
-package main
+package day07
 
 import (
     "math"
-    "regexp"
     "sort"
-    "strconv"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
-func linesToInts(lines []string) []int {
-    ints := make([]int, 0)
-    for _, line := range lines {
-        intList := regexp.MustCompile(",").Split(line, -1)
-        for _, intString := range intList {
-            integer, _ := strconv.Atoi(intString)
-            ints = append(ints, integer)
-        }
-    }
-    return ints
-}
-
 func avg(a, b int) int {
     return (a + b) / 2
 }
@@ -55,21 +36,37 @@ func max(ints []int) int {
     return max
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
-    crabXs := linesToInts(lines)
-    logger.Logs.Infof("Crab positions: %d", crabXs)
+func init() {
+    runner.Register(7, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    crabXs, err := parser.IntLines(lines, ",")
+    if err != nil {
+        return 0, err
+    }
+    logs.Infof("Crab positions: %d", crabXs)
     finalPosition, fuel := median(crabXs), 0
     for _, position := range crabXs {
         fuel += int(math.Abs(float64(position) - float64(finalPosition)))
     }
-    return fuel
+    return fuel, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    crabXs := linesToInts(lines)
-    logger.Logs.Infof("Crab positions: %d", crabXs)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    crabXs, err := parser.IntLines(lines, ",")
+    if err != nil {
+        return 0, err
+    }
+    logs.Infof("Crab positions: %d", crabXs)
     fuel, minFuel := 0, -1
     for position := 0; position < max(crabXs); position++ {
         fuel = 0
@@ -81,5 +78,5 @@ func part2() int {
             minFuel = fuel
         }
     }
-    return minFuel
+    return minFuel, nil
 }
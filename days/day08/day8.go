@@ -1,21 +1,15 @@
-package main
+package day08
 
 import (
     "fmt"
     "regexp"
-    "strconv"
     "strings"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
 func splitReg(str string, sep string) []string {
     return regexp.MustCompile(sep).Split(str, -1)
 }
@@ -171,18 +165,23 @@ func (d *Decoder) DecodeInputs(strings []string) {
     }
 }
 
-func (d *Decoder) DecodeOutputs(strings []string) []int {
+func (d *Decoder) DecodeOutputs(strings []string) ([]int, error) {
     results := make([]int, 0)
     for i := range strings {
         s := SetFrom(strings[i])
+        found := false
         for j, set := range d.digits {
             if set.Equals(s) {
                 results = append(results, j)
+                found = true
                 break
             }
         }
+        if ! found {
+            return nil, fmt.Errorf("output %q doesn't match any decoded digit", strings[i])
+        }
     }
-    return results
+    return results, nil
 }
 
 
@@ -192,11 +191,14 @@ func NewDecoder() *Decoder {
     return d
 }
 
-func inputsOutputs(lines []string) []map[string][]string {
+func inputsOutputs(lines []string) ([]map[string][]string, error) {
     result := make([]map[string][]string, 0)
-    for _, line := range lines {
+    for i, line := range lines {
         //logger.Logs.Infof("Parsing line: %s", line)
         inputOutput := splitReg(line, " \\| ")
+        if len(inputOutput) != 2 {
+            return nil, &parser.LineError{Line: i + 1, Err: fmt.Errorf("expected signal patterns, \" | \" and output digits")}
+        }
         //logger.Logs.Infof("Parsed line out of ' | ' into inputs, outputs: %s", inputOutput)
         inputs := splitReg(inputOutput[0], " ")
         outputs := splitReg(inputOutput[1], " ")
@@ -205,13 +207,23 @@ func inputsOutputs(lines []string) []map[string][]string {
         entry["outputs"] = outputs
         result = append(result, entry)
     }
-    return result
+    return result, nil
+}
+
+func init() {
+    runner.Register(8, part1, part2)
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
     sum := 0
-    entries := inputsOutputs(lines)
+    entries, err := inputsOutputs(lines)
+    if err != nil {
+        return 0, err
+    }
     for i := range entries {
         // logger.Logs.Infof("%dth entry (previously a line of input): %s", i, entries[i])
         for _, output := range entries[i]["outputs"] {
@@ -229,27 +241,35 @@ func part1() int {
             }
         }
     }
-    return sum
+    return sum, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    entries := inputsOutputs(lines)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    entries, err := inputsOutputs(lines)
+    if err != nil {
+        return 0, err
+    }
     sum := 0
     for i := range entries {
         // logger.Logs.Infof("%dth entry (previously a line of input): %s", i, entries[i])
         decoder := NewDecoder()
         decoder.DecodeInputs(entries[i]["inputs"])
         //logger.Logs.Infof("Decoded inputs: %v", decoder.digits)
-        results := decoder.DecodeOutputs(entries[i]["outputs"])
+        results, err := decoder.DecodeOutputs(entries[i]["outputs"])
+        if err != nil {
+            return 0, &parser.LineError{Line: i + 1, Err: err}
+        }
         // logger.Logs.Infof("Encoded outputs: %v", entries[i]["outputs"])
         // logger.Logs.Infof("Decoded outputs: %v", results)
-        str := ""
+        value := 0
         for num := range results {
-            str += strconv.Itoa(results[num])
+            value = value * 10 + results[num]
         }
-        tmp, _ := strconv.Atoi(str)
-        sum += tmp
+        sum += value
     }
-    return sum
+    return sum, nil
 }
@@ -0,0 +1,106 @@
+package day09
+
+import (
+    "sort"
+    grid "advent2021/adventgrid"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+type Board struct {
+    heights *grid.Grid[int]
+    tracked map[grid.Point]struct{}
+}
+
+func boardFromInput(lines []string) (*Board, error) {
+    heights, err := grid.Digits(lines)
+    if err != nil {
+        return nil, err
+    }
+    board := Board{heights: heights, tracked: make(map[grid.Point]struct{})}
+    return &board, nil
+}
+
+func (b *Board) IsLowPoint(point grid.Point) bool {
+    for _, neighbour := range b.heights.Neighbours4(point) {
+        if b.heights.Get(neighbour) <= b.heights.Get(point) {
+            //logger.Logs.Infof("Not low: Point %v (height %d) is at a greater than or equal height to comparison point %v (height %d)", point, b.heights.Get(point), neighbour, b.heights.Get(neighbour))
+            return false
+        }
+    }
+    //logger.Logs.Infof("LOW: Point %v (height %d) is at a lesser height than all 4 comparison points", point, b.heights.Get(point))
+    return true
+}
+
+func (b *Board) ClearTracked() {
+    b.tracked = make(map[grid.Point]struct{})
+}
+
+func (b *Board) SearchBasinFrom(low grid.Point) int {
+    // logger.Logs.Infof("Starting new basin search at point %v", low)
+    b.ClearTracked()
+    b.tracked[low] = struct{}{}
+    return b.SearchBasin(low, 1)
+}
+
+func (b *Board) SearchBasin(curr grid.Point, accum int) int {
+    // logger.Logs.Infof("Searching basin at point %v", curr)
+    b.tracked[curr] = struct{}{}
+    if b.heights.Get(curr) == 9 {
+        accum -= 1 // don't count peaks
+        // logger.Logs.Infof("Stop recursing: point %v (height %d) is a peak. Accumulator at %d", curr, b.heights.Get(curr), accum)
+        return accum
+    }
+    for _, next := range b.heights.Neighbours4(curr) {
+        if _, ok := b.tracked[next]; ! ok {
+            // logger.Logs.Infof("Recurse from point %v: Point %v (height %d) hasn't been visited yet", curr, next, b.heights.Get(next))
+            accum = b.SearchBasin(next, accum + 1)
+        }
+    }
+    return accum
+}
+
+func init() {
+    runner.Register(9, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    sum := 0
+    board.heights.Each(func(point grid.Point, height int) {
+        if board.IsLowPoint(point) {
+            sum += height + 1
+        }
+    })
+    return sum, nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    basins := make([]int, 0)
+    board.heights.Each(func(point grid.Point, _ int) {
+        if board.IsLowPoint(point) {
+            basin := board.SearchBasinFrom(point)
+            // logger.Logs.Infof("Searched basin at point %v, got area %d", point, basin)
+            basins = append(basins, basin)
+        }
+    })
+    // logger.Logs.Infof("Collected all basins info: %v", basins)
+    sort.Sort(sort.Reverse(sort.IntSlice(basins)))
+    return basins[0] * basins[1] * basins[2], nil
+}
@@ -1,4 +1,4 @@
-package main
+package day10
 
 import (
     "fmt"
@@ -6,15 +6,9 @@ import (
     "strings"
     logger "advent2021/adventlogger"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
-}
-
 var Openers = map[string]string{
     "[": "]",
     "(": ")",
@@ -88,8 +82,15 @@ func (s *Stack) Tail() string {
     return (*s)[len(*s) - 1]
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
+func init() {
+    runner.Register(10, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
     points := 0
     for _, line := range lines {
         s := make(Stack, 0)
@@ -100,11 +101,14 @@ func part1() int {
             }
         }
     }
-    return points
+    return points, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
     scores := make([]int, 0)
     points := 0
     LINES:
@@ -129,5 +133,5 @@ func part2() int {
         scores = append(scores, points)
     }
     sort.Ints(scores)
-    return scores[len(scores) / 2]
+    return scores[len(scores) / 2], nil
 }
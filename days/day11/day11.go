@@ -0,0 +1,134 @@
+package day11
+
+import (
+    "fmt"
+    "strconv"
+    grid "advent2021/adventgrid"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+type Octopus struct {
+    energy int
+    flash bool
+}
+
+func (o Octopus) String() string {
+    return fmt.Sprintf("Energy: %b, flashed: %t", o.energy, o.flash)
+}
+
+func (o *Octopus) Increment() {
+    o.energy += 1
+}
+
+func (o *Octopus) Flash() {
+    o.flash = true
+}
+
+func (o *Octopus) Reset() {
+    if o.flash {
+        o.energy = 0
+    }
+    o.flash = false
+}
+
+type Board struct {
+    octopuses *grid.Grid[*Octopus]
+    flashes, stepFlashes int
+}
+
+func (b *Board) Print() {
+    fmt.Print(b.octopuses.Sprint("", func(_ grid.Point, o *Octopus) string {
+        return strconv.Itoa(o.energy)
+    }))
+}
+
+func boardFromInput(lines []string) (*Board, error) {
+    octopuses, err := grid.Parse(lines, func(char byte) (*Octopus, error) {
+        if char < '0' || char > '9' {
+            return nil, fmt.Errorf("%q is not an energy level", char)
+        }
+        return &Octopus{energy: int(char - '0')}, nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    board := Board{octopuses: octopuses}
+    return &board, nil
+}
+
+func (b *Board) Increment() {
+    b.octopuses.Each(func(_ grid.Point, octopus *Octopus) {
+        octopus.Increment()
+    })
+}
+
+func (b *Board) Reset() {
+    b.octopuses.Each(func(_ grid.Point, octopus *Octopus) {
+        if octopus.energy > 9 {
+            octopus.Reset()
+        }
+    })
+}
+
+func (b *Board) Step() bool {
+    b.stepFlashes = 0
+    b.Increment()
+    b.octopuses.Each(func(point grid.Point, _ *Octopus) {
+        b.ResolveFlash(point)
+    })
+    b.Reset()
+    return b.stepFlashes != b.octopuses.Width() * b.octopuses.Height()
+}
+
+
+func (b *Board) ResolveFlash(p grid.Point) {
+    octopus := b.octopuses.Get(p)
+    if octopus.energy <= 9 || octopus.flash {
+        return
+    }
+    octopus.Flash()
+    b.flashes += 1
+    b.stepFlashes += 1
+    for _, neighborPoint := range b.octopuses.Neighbours8(p) {
+        b.octopuses.Get(neighborPoint).energy += 1
+        b.ResolveFlash(neighborPoint)
+    }
+}
+
+func init() {
+    runner.Register(11, part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    for i := 0; i < 100 ; i++ {
+        board.Step()
+    }
+    return board.flashes, nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    board.Print()
+    i := 0
+    for board.Step() {
+        i+= 1
+    }
+    return i + 1, nil // last call to board.Step() was false so i didn't increment
+}
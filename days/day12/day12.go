@@ -1,4 +1,4 @@
-package main
+package day12
 
 import (
     "fmt"
@@ -6,6 +6,7 @@ import (
     "strings"
     logger "advent2021/adventlogger"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 // Replace 'start' with '0' and 'end' with '1'; they could match node labels
@@ -129,24 +130,27 @@ func LinesToNodes(lines []string) *NodeMap {
     return nodeMap
 }
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
+func init() {
+    runner.Register(12, part1, part2)
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
     nodeMap := LinesToNodes(lines)
     // nodeMap.Print()
     numPaths := nodeMap.nodeMap["start"].Walk("")
-    return numPaths
+    return numPaths, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
     nodeMap := LinesToNodes(lines)
     numPaths := nodeMap.nodeMap["start"].ShittyWalk(ShittyPath{"", false})
-    return numPaths
+    return numPaths, nil
 }
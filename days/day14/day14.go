@@ -1,9 +1,12 @@
-package main
+package day14
 
 import (
+    "fmt"
     "regexp"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 type Polymer struct {
@@ -55,34 +58,60 @@ func (p *Polymer) MinMax() {
     }
 }
 
-func polymerFromInput(lines []string) *Polymer {
+var ruleReg = regexp.MustCompile(`^(?P<pair>[A-Z][A-Z])\s->\s(?P<insert>[A-Z])$`)
+
+type rule struct {
+    Pair, Insert string
+}
+
+func polymerFromInput(lines []string) (*Polymer, error) {
+    blocks := parser.Blocks(lines)
+    if len(blocks) != 2 || len(blocks[0].Lines) != 1 {
+        return nil, fmt.Errorf("expected a template line, a blank line and insertion rules")
+    }
     polymer := NewPolymer()
-    template, _, instructLines := lines[0], lines[1], lines[2:]
+    template := blocks[0].Lines[0]
+    if len(template) < 2 {
+        return nil, fmt.Errorf("template %q is too short to have pairs", template)
+    }
     for _, char := range template {
         polymer.counts[string(char)] += 1
     }
     polymer.pairs = stringToPairs(template)
-    for _, line := range instructLines {
-        reg := regexp.MustCompile(`([A-Z][A-Z])\s->\s([A-Z])`)
-        result := reg.FindStringSubmatch(line)
-        polymer.instructs[result[1]] = result[2]
+    rules := make([]rule, 0)
+    if err := parser.MatchLines(ruleReg, blocks[1].Lines, &rules); err != nil {
+        return nil, blocks[1].Err(err)
+    }
+    for _, r := range rules {
+        polymer.instructs[r.Pair] = r.Insert
     }
-    return polymer
+    return polymer, nil
 }
 
-func main() {
-    result := run(10)
-    logger.Logs.Infof("Part one result: %d", result)
-    result = run(40)
-    logger.Logs.Infof("Part two result: %d", result)
+func init() {
+    runner.Register(14, part1, part2)
 }
 
-func run(iterations int) int {
-    lines := reader.LinesFromFile("input.txt")
-    polymer := polymerFromInput(lines)
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    return run(src, 10)
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    return run(src, 40)
+}
+
+func run(src reader.Source, iterations int) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    polymer, err := polymerFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
     for i := 0; i < iterations; i++ {
         polymer.Step()
     }
     polymer.MinMax()
-    return polymer.max - polymer.min
+    return polymer.max - polymer.min, nil
 }
@@ -0,0 +1,164 @@
+package day15
+
+import (
+    "fmt"
+    "strconv"
+    graph "advent2021/adventgraph"
+    grid "advent2021/adventgrid"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+const ColorGreen ="\033[1;32m%s\033[0m"
+const ColorNone ="%s"
+
+// Board is the cave, tiled tiles times in each direction. The tiles aren't
+// stored: each tile's risk is the original's plus its distance in tiles from
+// the top left one, wrapping from 9 back round to 1, so it's worked out on
+// the fly from the original grid.
+type Board struct {
+    costs *grid.Grid[int]
+    tiles int
+    width, height int
+    path map[grid.Point]bool
+}
+
+func NewBoard(costs *grid.Grid[int], tiles int) *Board {
+    board := Board{
+        costs: costs,
+        tiles: tiles,
+        width: costs.Width() * tiles,
+        height: costs.Height() * tiles,
+        path: make(map[grid.Point]bool),
+    }
+    return &board
+}
+
+func (b *Board) In(p grid.Point) bool {
+    return p.X >= 0 && p.Y >= 0 && p.X < b.width && p.Y < b.height
+}
+
+// Cost is the risk of entering p.
+func (b *Board) Cost(p grid.Point) int {
+    w, h := b.costs.Width(), b.costs.Height()
+    base := b.costs.Get(grid.Point{X: p.X % w, Y: p.Y % h})
+    return (base + p.X / w + p.Y / h - 1) % 9 + 1
+}
+
+func (b *Board) Print() {
+    for y := 0; y < b.height; y++ {
+        line := ""
+        for x := 0; x < b.width; x++ {
+            p := grid.Point{X: x, Y: y}
+            color := ColorNone
+            if b.path[p] {
+                color = ColorGreen
+            }
+            if x > 0 {
+                line += " "
+            }
+            line += fmt.Sprintf(color, strconv.Itoa(b.Cost(p)))
+        }
+        fmt.Println(line)
+    }
+}
+
+// Neighbours makes the board a graph for the path search: you can step to
+// any orthogonal neighbour, paying its risk to enter it.
+func (b *Board) Neighbours(p grid.Point) []graph.Edge[grid.Point] {
+    edges := make([]graph.Edge[grid.Point], 0, 4)
+    for _, next := range p.Neighbours4() {
+        if b.In(next) {
+            edges = append(edges, graph.Edge[grid.Point]{To: next, Cost: b.Cost(next)})
+        }
+    }
+    return edges
+}
+
+// FindPath finds the lowest risk route from the top left to the bottom right
+// with either "astar" or "dijkstra", logging how much searching it took. The
+// path starts at the top left, whose risk isn't counted.
+func (b *Board) FindPath(algorithm string, logs *logger.Logger) (graph.Path[grid.Point], error) {
+    origin := grid.Point{}
+    final := grid.Point{X: b.width - 1, Y: b.height - 1}
+    isFinal := func(p grid.Point) bool { return p == final }
+    var path graph.Path[grid.Point]
+    var stats graph.Stats
+    var err error
+    switch algorithm {
+    case "astar":
+        path, stats, err = graph.AStar[grid.Point](b, origin, isFinal, graph.Manhattan(final))
+    case "dijkstra":
+        path, stats, err = graph.Dijkstra[grid.Point](b, origin, isFinal)
+    default:
+        return path, fmt.Errorf("unknown algorithm %q, want astar or dijkstra", algorithm)
+    }
+    if err != nil {
+        return path, err
+    }
+    logs.WithFields(logger.Fields{
+        "algorithm": algorithm,
+        "expanded": stats.Expanded,
+        "pushed": stats.Pushed,
+        "steps": len(path.Nodes) - 1,
+    }).Infof("Found a path of risk %d", path.Cost)
+    return path, nil
+}
+
+// KayakDotCom finds the lowest risk and marks the route for Print.
+func (b *Board) KayakDotCom(logs *logger.Logger) (int, error) {
+    path, err := b.FindPath("astar", logs)
+    if err != nil {
+        return 0, err
+    }
+    for _, p := range path.Nodes {
+        b.path[p] = true
+    }
+    return path.Cost, nil
+}
+
+func boardFromInput(lines []string, tiles int) (*Board, error) {
+    costs, err := grid.Digits(lines)
+    if err != nil {
+        return nil, err
+    }
+    if costs.Width() == 0 {
+        return nil, fmt.Errorf("cave map is empty")
+    }
+    if tiles < 1 {
+        return nil, fmt.Errorf("can't tile the cave %d times", tiles)
+    }
+    return NewBoard(costs, tiles), nil
+}
+
+func init() {
+    runner.Register(15, part1, part2)
+    runner.RegisterTool(15, runner.Tool{
+        Name: "path",
+        Summary: "export the lowest risk route as a coordinate list and a PNG heatmap",
+        Run: pathTool,
+    })
+}
+
+// solve finds the lowest risk across the cave tiled tiles times each way.
+func solve(src reader.Source, tiles int, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines, tiles)
+    if err != nil {
+        return 0, err
+    }
+    // `advent tool 15 path` draws the route
+    return board.KayakDotCom(logs)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    return solve(src, 1, logs)
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    return solve(src, 5, logs)
+}
@@ -0,0 +1,125 @@
+package day15
+
+import (
+    "bufio"
+    "flag"
+    "fmt"
+    "image"
+    "image/color"
+    "image/png"
+    "io"
+    grid "advent2021/adventgrid"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// WritePath writes the route one "x,y" point per line, from the start to the
+// end, so two routes can be compared with diff.
+func WritePath(w io.Writer, path []grid.Point) error {
+    buf := bufio.NewWriter(w)
+    for _, p := range path {
+        fmt.Fprintln(buf, p)
+    }
+    return buf.Flush()
+}
+
+var pathColor = color.RGBA{255, 255, 255, 255}
+
+// heat colours a risk from 1 (dark blue) through green to 9 (bright red).
+func heat(risk int) color.RGBA {
+    t := float64(risk - 1) / 8
+    ramp := func(from, to float64) uint8 { return uint8(from + (to - from) * t) }
+    if t < 0.5 {
+        t *= 2
+        return color.RGBA{ramp(20, 40), ramp(30, 170), ramp(120, 60), 255}
+    }
+    t = (t - 0.5) * 2
+    return color.RGBA{ramp(40, 230), ramp(170, 30), ramp(60, 20), 255}
+}
+
+// WritePNG draws the cave as a heatmap of risk, scale pixels to a point, with
+// the route over it in white.
+func (b *Board) WritePNG(w io.Writer, path []grid.Point, scale int) error {
+    if scale < 1 {
+        return fmt.Errorf("scale must be at least 1, got %d", scale)
+    }
+    onPath := make(map[grid.Point]bool, len(path))
+    for _, p := range path {
+        onPath[p] = true
+    }
+    img := image.NewRGBA(image.Rect(0, 0, b.width * scale, b.height * scale))
+    for y := 0; y < b.height; y++ {
+        for x := 0; x < b.width; x++ {
+            p := grid.Point{X: x, Y: y}
+            c := heat(b.Cost(p))
+            if onPath[p] {
+                c = pathColor
+            }
+            for dy := 0; dy < scale; dy++ {
+                for dx := 0; dx < scale; dx++ {
+                    img.SetRGBA(x * scale + dx, y * scale + dy, c)
+                }
+            }
+        }
+    }
+    return png.Encode(w, img)
+}
+
+func pathTool(args []string) error {
+    fs := flag.NewFlagSet("path", flag.ContinueOnError)
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    tiles := fs.Int("tiles", 1, "how many times to tile the cave each way (5 for part 2)")
+    algorithm := fs.String("algorithm", "astar", "astar or dijkstra")
+    txt := fs.String("txt", "", "write the route as x,y lines to this file (- for stdout)")
+    pngFile := fs.String("png", "", "write a heatmap with the route drawn on to this file")
+    scale := fs.Int("scale", 0, "pixels per point in the PNG; 0 picks one that makes it about 500 pixels wide")
+    show := fs.Bool("print", false, "print the cave with the route highlighted")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+    lines, err := reader.ReadLines(reader.SourceFor(15, *input))
+    if err != nil {
+        return err
+    }
+    board, err := boardFromInput(lines, *tiles)
+    if err != nil {
+        return err
+    }
+    path, err := board.FindPath(*algorithm, logger.Logs)
+    if err != nil {
+        return err
+    }
+    if *show {
+        for _, p := range path.Nodes {
+            board.path[p] = true
+        }
+        board.Print()
+    }
+    if *txt != "" {
+        err := runner.WriteOutput(*txt, func(w io.Writer) error {
+            return WritePath(w, path.Nodes)
+        })
+        if err != nil {
+            return err
+        }
+    }
+    if *pngFile != "" {
+        if *scale == 0 {
+            *scale = 500 / board.width
+            if *scale < 1 {
+                *scale = 1
+            }
+        }
+        err := runner.WriteOutput(*pngFile, func(w io.Writer) error {
+            return board.WritePNG(w, path.Nodes, *scale)
+        })
+        if err != nil {
+            return err
+        }
+        if *pngFile != "-" {
+            logger.Logs.Infof("Wrote %s", *pngFile)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,62 @@
+package day15
+
+import (
+    "bytes"
+    "image"
+    "image/color"
+    "image/png"
+    "testing"
+    grid "advent2021/adventgrid"
+)
+
+func TestWritePath(t *testing.T) {
+    var out bytes.Buffer
+    path := []grid.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 12, Y: 1}}
+    if err := WritePath(&out, path); err != nil {
+        t.Fatal(err)
+    }
+    if got, want := out.String(), "0,0\n0,1\n1,1\n12,1\n"; got != want {
+        t.Errorf("wrote %q, expected %q", got, want)
+    }
+}
+
+func TestWritePNG(t *testing.T) {
+    costs, err := grid.Digits([]string{"19", "55"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    board := NewBoard(costs, 1)
+    path := []grid.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+    var out bytes.Buffer
+    if err := board.WritePNG(&out, path, 2); err != nil {
+        t.Fatal(err)
+    }
+    if ! bytes.HasPrefix(out.Bytes(), []byte("\x89PNG\r\n\x1a\n")) {
+        t.Fatalf("output doesn't start with the PNG signature: %q", out.Bytes()[:8])
+    }
+    // only the top right point, risk 9, is off the route, and it's red
+    red := color.RGBA{230, 30, 20, 255}
+    want := image.NewRGBA(image.Rect(0, 0, 4, 4))
+    for y := 0; y < 4; y++ {
+        for x := 0; x < 4; x++ {
+            want.SetRGBA(x, y, pathColor)
+            if x >= 2 && y < 2 {
+                want.SetRGBA(x, y, red)
+            }
+        }
+    }
+    var expected bytes.Buffer
+    if err := png.Encode(&expected, want); err != nil {
+        t.Fatal(err)
+    }
+    if ! bytes.Equal(out.Bytes(), expected.Bytes()) {
+        img, err := png.Decode(bytes.NewReader(out.Bytes()))
+        if err != nil {
+            t.Fatalf("wrote %d bytes that don't decode: %v", out.Len(), err)
+        }
+        t.Errorf("wrote a %v image, expected 4x4 with the top right quarter %v", img.Bounds(), red)
+    }
+    if err := board.WritePNG(&out, path, 0); err == nil {
+        t.Errorf("a scale of 0 should be an error")
+    }
+}
@@ -0,0 +1,141 @@
+package day16
+
+import (
+    "errors"
+    "fmt"
+)
+
+// ErrTruncated means the transmission ran out of bits partway through a read.
+var ErrTruncated = errors.New("transmission is truncated")
+
+// BitReader reads a transmission a few bits at a time, most significant bit
+// of the first byte first.
+type BitReader struct {
+    data []byte
+    pos int // bits read so far
+}
+
+func NewBitReader(data []byte) *BitReader {
+    return &BitReader{data: data}
+}
+
+// HexBytes decodes a hex transmission. An odd number of digits is fine; the
+// last one fills the top of the final byte.
+func HexBytes(hex string) ([]byte, error) {
+    data := make([]byte, (len(hex) + 1) / 2)
+    for i := 0; i < len(hex); i++ {
+        var nibble byte
+        switch char := hex[i]; {
+        case char >= '0' && char <= '9':
+            nibble = char - '0'
+        case char >= 'A' && char <= 'F':
+            nibble = char - 'A' + 10
+        case char >= 'a' && char <= 'f':
+            nibble = char - 'a' + 10
+        default:
+            return nil, fmt.Errorf("column %d: %q is not a hex digit", i + 1, char)
+        }
+        if i % 2 == 0 {
+            nibble <<= 4
+        }
+        data[i / 2] |= nibble
+    }
+    return data, nil
+}
+
+// Pos is how many bits have been read.
+func (r *BitReader) Pos() int {
+    return r.pos
+}
+
+// Len is the total number of bits in the transmission.
+func (r *BitReader) Len() int {
+    return len(r.data) * 8
+}
+
+// Remaining is how many bits are left to read.
+func (r *BitReader) Remaining() int {
+    return r.Len() - r.pos
+}
+
+// ReadBits reads the next n (up to 64) bits as an unsigned number. If there
+// aren't n bits left it reads nothing and returns ErrTruncated.
+func (r *BitReader) ReadBits(n int) (uint64, error) {
+    if n < 0 || n > 64 {
+        return 0, fmt.Errorf("can't read %d bits at once", n)
+    }
+    if n > r.Remaining() {
+        return 0, fmt.Errorf("reading %d bits at bit %d of %d: %w", n, r.pos, r.Len(), ErrTruncated)
+    }
+    var value uint64
+    for n > 0 {
+        // take as many bits as we can from the current byte
+        offset := r.pos % 8
+        take := 8 - offset
+        if take > n {
+            take = n
+        }
+        bits := r.data[r.pos / 8] >> (8 - offset - take) & (1 << take - 1)
+        value = value << take | uint64(bits)
+        r.pos += take
+        n -= take
+    }
+    return value, nil
+}
+
+// readInt is ReadBits for fields that comfortably fit an int.
+func (r *BitReader) readInt(n int) (int, error) {
+    value, err := r.ReadBits(n)
+    return int(value), err
+}
+
+// BitWriter builds a transmission a few bits at a time, the mirror image of
+// BitReader.
+type BitWriter struct {
+    data []byte
+    pos int // bits written so far
+}
+
+// Pos is how many bits have been written.
+func (w *BitWriter) Pos() int {
+    return w.pos
+}
+
+// WriteBits writes the low n (up to 64) bits of value, most significant first.
+func (w *BitWriter) WriteBits(value uint64, n int) error {
+    if n < 0 || n > 64 {
+        return fmt.Errorf("can't write %d bits at once", n)
+    }
+    if n < 64 && value >> n != 0 {
+        return fmt.Errorf("%d doesn't fit in %d bits", value, n)
+    }
+    for i := n - 1; i >= 0; i-- {
+        if w.pos % 8 == 0 {
+            w.data = append(w.data, 0)
+        }
+        if value >> i & 1 == 1 {
+            w.data[w.pos / 8] |= 0x80 >> (w.pos % 8)
+        }
+        w.pos++
+    }
+    return nil
+}
+
+// Append writes everything other has written.
+func (w *BitWriter) Append(other *BitWriter) {
+    for i := 0; i < other.pos; i++ {
+        bit := other.data[i / 8] >> (7 - i % 8) & 1
+        w.WriteBits(uint64(bit), 1)
+    }
+}
+
+// Bytes returns what's been written, zero padded to a whole byte.
+func (w *BitWriter) Bytes() []byte {
+    return w.data
+}
+
+// Hex returns what's been written as upper case hex, zero padded to a whole
+// byte like the puzzle's transmissions.
+func (w *BitWriter) Hex() string {
+    return fmt.Sprintf("%X", w.data)
+}
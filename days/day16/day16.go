@@ -0,0 +1,319 @@
+package day16
+
+import (
+    "fmt"
+    "math/big"
+    "strings"
+    logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+const (
+    TypeSum = iota
+    TypeProduct
+    TypeMin
+    TypeMax
+    TypeLiteral
+    TypeGreaterThan
+    TypeLessThan
+    TypeEqualTo
+)
+
+// length type IDs for operators
+const (
+    LengthBits = 0  // the next 15 bits are the sub-packets' total length in bits
+    LengthCount = 1 // the next 11 bits are the number of sub-packets
+)
+
+type Packet struct {
+    version, packType int
+    value int         // a literal's value, if it fits in 63 bits
+    bigValue *big.Int // a literal's value when it doesn't, otherwise nil
+    lengthType int
+    subPackets []*Packet
+    start, end int // bit offsets of the packet in the transmission
+}
+
+func (p Packet) String() string {
+    if p.packType == TypeLiteral {
+        return fmt.Sprintf("Version: '%d', Type: '%d', Value: '%v'", p.version, p.packType, p.Literal())
+    }
+    return fmt.Sprintf("Version: '%d', Type: '%d', Length type: '%d', SubPackets: '%v'", p.version, p.packType, p.lengthType, p.subPackets)
+}
+
+// Literal returns a literal packet's value.
+func (p *Packet) Literal() *big.Int {
+    if p.bigValue != nil {
+        return p.bigValue
+    }
+    return big.NewInt(int64(p.value))
+}
+
+func (p *Packet) VersionSum() int {
+    sum := p.version
+    for _, subPack := range p.subPackets {
+        sum += subPack.VersionSum()
+    }
+    return sum
+}
+
+func (p *Packet) Sum() *big.Int {
+    sum := new(big.Int)
+    for _, packet := range p.subPackets {
+        sum.Add(sum, packet.Resolve())
+    }
+    return sum
+}
+
+func (p *Packet) Product() *big.Int {
+    result := big.NewInt(1)
+    for _, packet := range p.subPackets {
+        result.Mul(result, packet.Resolve())
+    }
+    return result
+}
+
+func (p *Packet) Min() *big.Int {
+    var min *big.Int
+    for _, packet := range p.subPackets {
+        val := packet.Resolve()
+        if min == nil || val.Cmp(min) < 0 {
+            min = val
+        }
+    }
+    return min
+}
+
+func (p *Packet) Max() *big.Int {
+    var max *big.Int
+    for _, packet := range p.subPackets {
+        val := packet.Resolve()
+        if max == nil || val.Cmp(max) > 0 {
+            max = val
+        }
+    }
+    return max
+}
+
+// compare is the shared part of the comparison operators: 1 if the two
+// sub-packets compare the way want says, otherwise 0.
+func (p *Packet) compare(want func(cmp int) bool) *big.Int {
+    if want(p.subPackets[0].Resolve().Cmp(p.subPackets[1].Resolve())) {
+        return big.NewInt(1)
+    }
+    return big.NewInt(0)
+}
+
+func (p *Packet) GreaterThan() *big.Int {
+    return p.compare(func(cmp int) bool { return cmp > 0 })
+}
+
+func (p *Packet) LessThan() *big.Int {
+    return p.compare(func(cmp int) bool { return cmp < 0 })
+}
+
+func (p *Packet) EqualTo() *big.Int {
+    return p.compare(func(cmp int) bool { return cmp == 0 })
+}
+
+// Resolve evaluates the packet. Decode has already checked every operator
+// has the sub-packets it needs.
+func (p *Packet) Resolve() *big.Int {
+    switch p.packType {
+    case TypeSum:
+        return p.Sum()
+    case TypeProduct:
+        return p.Product()
+    case TypeMin:
+        return p.Min()
+    case TypeMax:
+        return p.Max()
+    case TypeLiteral:
+        return p.Literal()
+    case TypeGreaterThan:
+        return p.GreaterThan()
+    case TypeLessThan:
+        return p.LessThan()
+    default:
+        return p.EqualTo()
+    }
+}
+
+// Parse reads one packet, and everything inside it, from r.
+func Parse(r *BitReader) (*Packet, error) {
+    p := &Packet{start: r.Pos()}
+    var err error
+    if p.version, err = r.readInt(3); err != nil {
+        return nil, err
+    }
+    if p.packType, err = r.readInt(3); err != nil {
+        return nil, err
+    }
+    if p.packType == TypeLiteral {
+        err = p.parseLiteral(r)
+    } else {
+        err = p.parseOperator(r)
+    }
+    if err != nil {
+        return nil, err
+    }
+    p.end = r.Pos()
+    return p, nil
+}
+
+// parseLiteral reads groups of 5 bits, 4 of value and a leading 1 on all but
+// the last group.
+func (p *Packet) parseLiteral(r *BitReader) error {
+    for {
+        group, err := r.ReadBits(5)
+        if err != nil {
+            return err
+        }
+        nibble := int64(group & 0xF)
+        switch {
+        case p.bigValue != nil:
+            p.bigValue.Lsh(p.bigValue, 4).Or(p.bigValue, big.NewInt(nibble))
+        case p.value >= 1 << 59:
+            // another nibble would overflow an int
+            p.bigValue = big.NewInt(int64(p.value))
+            p.bigValue.Lsh(p.bigValue, 4).Or(p.bigValue, big.NewInt(nibble))
+        default:
+            p.value = p.value << 4 | int(nibble)
+        }
+        if group & 0x10 == 0 {
+            return nil
+        }
+    }
+}
+
+func (p *Packet) parseOperator(r *BitReader) error {
+    var err error
+    if p.lengthType, err = r.readInt(1); err != nil {
+        return err
+    }
+    if p.lengthType == LengthBits {
+        subPacketsBitLength, err := r.readInt(15)
+        if err != nil {
+            return err
+        }
+        end := r.Pos() + subPacketsBitLength
+        if end > r.Len() {
+            return fmt.Errorf("packet at bit %d says its sub-packets are %d bits long, but only %d are left: %w", p.start, subPacketsBitLength, r.Remaining(), ErrTruncated)
+        }
+        for r.Pos() < end {
+            subPacket, err := Parse(r)
+            if err != nil {
+                return err
+            }
+            p.subPackets = append(p.subPackets, subPacket)
+        }
+        if r.Pos() != end {
+            return fmt.Errorf("packet at bit %d: sub-packets run %d bits past their %d bit length", p.start, r.Pos() - end, subPacketsBitLength)
+        }
+    } else {
+        subPacketsLength, err := r.readInt(11)
+        if err != nil {
+            return err
+        }
+        for i := 0; i < subPacketsLength; i++ {
+            subPacket, err := Parse(r)
+            if err != nil {
+                return err
+            }
+            p.subPackets = append(p.subPackets, subPacket)
+        }
+    }
+    switch {
+    case p.packType >= TypeGreaterThan && len(p.subPackets) != 2:
+        return fmt.Errorf("comparison packet at bit %d has %d sub-packets, expected 2", p.start, len(p.subPackets))
+    case len(p.subPackets) == 0:
+        return fmt.Errorf("operator packet at bit %d has no sub-packets", p.start)
+    }
+    return nil
+}
+
+// Decode parses a hex transmission into its outermost packet. Anything after
+// it must be zero padding.
+func Decode(hex string) (*Packet, error) {
+    data, err := HexBytes(strings.TrimSpace(hex))
+    if err != nil {
+        return nil, err
+    }
+    r := NewBitReader(data)
+    packet, err := Parse(r)
+    if err != nil {
+        return nil, err
+    }
+    for r.Remaining() > 0 {
+        n := r.Remaining()
+        if n > 64 {
+            n = 64
+        }
+        if padding, _ := r.ReadBits(n); padding != 0 {
+            return nil, fmt.Errorf("non-zero bits after the packet ending at bit %d", packet.end)
+        }
+    }
+    return packet, nil
+}
+
+func packetsFromInput(lines []string) ([]*Packet, error) {
+    packets := make([]*Packet, 0)
+    for i, line := range lines {
+        if strings.TrimSpace(line) == "" {
+            continue
+        }
+        packet, err := Decode(line)
+        if err != nil {
+            return nil, &parser.LineError{Line: i + 1, Err: err}
+        }
+        packets = append(packets, packet)
+    }
+    if len(packets) == 0 {
+        return nil, fmt.Errorf("no transmission in the input")
+    }
+    return packets, nil
+}
+
+func init() {
+    runner.Register(16, part1, part2)
+    runner.RegisterTool(16, runner.Tool{
+        Name: "roundtrip",
+        Summary: "check random packets decode to what they were encoded from",
+        Run: roundtripTool,
+    })
+    runner.RegisterTool(16, runner.Tool{
+        Name: "disasm",
+        Summary: "list a transmission's packets and write it out as an expression",
+        Run: disasmTool,
+    })
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    packets, err := packetsFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    return packets[0].VersionSum(), nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    packets, err := packetsFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    value := packets[0].Resolve()
+    if ! value.IsInt64() {
+        return 0, fmt.Errorf("transmission evaluates to %v, which is too big for an answer", value)
+    }
+    return int(value.Int64()), nil
+}
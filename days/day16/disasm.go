@@ -0,0 +1,109 @@
+package day16
+
+import (
+    "flag"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    reader "advent2021/adventreader"
+)
+
+var typeNames = []string{"sum", "product", "min", "max", "literal", "gt", "lt", "eq"}
+
+// infix operators; min and max are written as calls instead
+var operators = map[int]string{
+    TypeSum: " + ",
+    TypeProduct: " * ",
+    TypeGreaterThan: " > ",
+    TypeLessThan: " < ",
+    TypeEqualTo: " == ",
+}
+
+// Disassemble writes one line per packet, sub-packets indented under their
+// operator: the bits the packet covers (up to but not including the second
+// offset), its version and type, how its sub-packets were counted, and what
+// it evaluates to.
+func Disassemble(w io.Writer, p *Packet) {
+    disassemble(w, p, 0)
+}
+
+func disassemble(w io.Writer, p *Packet, depth int) {
+    fmt.Fprintf(w, "%5d-%-5d %sv%d %s", p.start, p.end, strings.Repeat("  ", depth), p.version, typeNames[p.packType])
+    if p.packType != TypeLiteral {
+        if p.lengthType == LengthBits {
+            last := p.subPackets[len(p.subPackets) - 1]
+            fmt.Fprintf(w, " (%d bits)", last.end - p.subPackets[0].start)
+        } else {
+            fmt.Fprintf(w, " (%d packets)", len(p.subPackets))
+        }
+    }
+    fmt.Fprintf(w, " = %v\n", p.Resolve())
+    for _, sub := range p.subPackets {
+        disassemble(w, sub, depth + 1)
+    }
+}
+
+// Infix writes the packet out as an expression, like (3 + (5 * 7)) > 2.
+func Infix(p *Packet) string {
+    return infix(p, true)
+}
+
+func infix(p *Packet, top bool) string {
+    if p.packType == TypeLiteral {
+        return p.Literal().String()
+    }
+    operands := make([]string, len(p.subPackets))
+    for i, sub := range p.subPackets {
+        operands[i] = infix(sub, len(p.subPackets) == 1 && top)
+    }
+    op, ok := operators[p.packType]
+    if ! ok {
+        return typeNames[p.packType] + "(" + strings.Join(operands, ", ") + ")"
+    }
+    if len(operands) == 1 {
+        // a sum or product of one thing is just that thing
+        return operands[0]
+    }
+    expr := strings.Join(operands, op)
+    if top {
+        return expr
+    }
+    return "(" + expr + ")"
+}
+
+func disasmTool(args []string) error {
+    fs := flag.NewFlagSet("disasm", flag.ContinueOnError)
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    hex := fs.String("hex", "", "disassemble this transmission instead of the input")
+    format := fs.String("format", "both", "listing, infix or both")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+    if *format != "listing" && *format != "infix" && *format != "both" {
+        return fmt.Errorf("unknown format %q, want listing, infix or both", *format)
+    }
+    lines := []string{*hex}
+    if *hex == "" {
+        var err error
+        if lines, err = reader.ReadLines(reader.SourceFor(16, *input)); err != nil {
+            return err
+        }
+    }
+    packets, err := packetsFromInput(lines)
+    if err != nil {
+        return err
+    }
+    for i, packet := range packets {
+        if i > 0 {
+            fmt.Println()
+        }
+        if *format != "infix" {
+            Disassemble(os.Stdout, packet)
+        }
+        if *format != "listing" {
+            fmt.Printf("%s = %v\n", Infix(packet), packet.Resolve())
+        }
+    }
+    return nil
+}
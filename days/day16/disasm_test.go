@@ -0,0 +1,78 @@
+package day16
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestInfix(t *testing.T) {
+    tests := []struct {
+        hex, infix string
+        value int64
+    }{
+        {"9C0141080250320F1802104A08", "(1 + 3) == (2 * 2)", 1},
+        {"C200B40A82", "1 + 2", 3},
+        {"04005AC33890", "6 * 9", 54},
+        {"880086C3E88112", "min(7, 8, 9)", 7},
+        {"CE00C43D881120", "max(7, 8, 9)", 9},
+        {"D8005AC2A8F0", "5 < 15", 1},
+        {"F600BC2D8F", "5 > 15", 0},
+        {"8A004A801A8002F478", "min(min(min(15)))", 15},
+        {"D2FE28", "2021", 2021},
+    }
+    for _, test := range tests {
+        packet, err := Decode(test.hex)
+        if err != nil {
+            t.Errorf("%s: %v", test.hex, err)
+            continue
+        }
+        if got := Infix(packet); got != test.infix {
+            t.Errorf("%s: got %s, expected %s", test.hex, got, test.infix)
+        }
+        if got := packet.Resolve(); ! got.IsInt64() || got.Int64() != test.value {
+            t.Errorf("%s: evaluates to %v, expected %d", test.hex, got, test.value)
+        }
+    }
+}
+
+func TestDisassemble(t *testing.T) {
+    tests := []struct {
+        hex string
+        listing []string
+    }{
+        {
+            // an eq counted in bits holding two operators counted in packets
+            "9C0141080250320F1802104A08",
+            []string{
+                "    0-102   v4 eq (80 bits) = 1",
+                "   22-62      v2 sum (2 packets) = 4",
+                "   40-51        v2 literal = 1",
+                "   51-62        v4 literal = 3",
+                "   62-102     v6 product (2 packets) = 4",
+                "   80-91        v0 literal = 2",
+                "   91-102       v2 literal = 2",
+            },
+        },
+        {
+            "38006F45291200",
+            []string{
+                "    0-49    v1 lt (27 bits) = 1",
+                "   22-33      v6 literal = 10",
+                "   33-49      v2 literal = 20",
+            },
+        },
+        {"D2FE28", []string{"    0-21    v6 literal = 2021"}},
+    }
+    for _, test := range tests {
+        packet, err := Decode(test.hex)
+        if err != nil {
+            t.Errorf("%s: %v", test.hex, err)
+            continue
+        }
+        var out strings.Builder
+        Disassemble(&out, packet)
+        if got, want := out.String(), strings.Join(test.listing, "\n") + "\n"; got != want {
+            t.Errorf("%s: got\n%s\nexpected\n%s", test.hex, got, want)
+        }
+    }
+}
@@ -0,0 +1,213 @@
+package day16
+
+import (
+    "flag"
+    "fmt"
+    "math/big"
+    "math/rand"
+    "strings"
+    "time"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+)
+
+// NewLiteral makes a literal packet. value must not be negative.
+func NewLiteral(version int, value *big.Int) *Packet {
+    p := &Packet{version: version, packType: TypeLiteral}
+    if value.BitLen() < 63 {
+        p.value = int(value.Int64())
+    } else {
+        p.bigValue = new(big.Int).Set(value)
+    }
+    return p
+}
+
+// NewOperator makes an operator packet that will be encoded with the given
+// length type.
+func NewOperator(version, packType, lengthType int, subPackets ...*Packet) *Packet {
+    return &Packet{version: version, packType: packType, lengthType: lengthType, subPackets: subPackets}
+}
+
+// Encode turns a packet back into a hex transmission.
+func Encode(p *Packet) (string, error) {
+    w := &BitWriter{}
+    if err := p.encode(w); err != nil {
+        return "", err
+    }
+    return w.Hex(), nil
+}
+
+func (p *Packet) encode(w *BitWriter) error {
+    if err := w.WriteBits(uint64(p.version), 3); err != nil {
+        return fmt.Errorf("version: %w", err)
+    }
+    if err := w.WriteBits(uint64(p.packType), 3); err != nil {
+        return fmt.Errorf("type: %w", err)
+    }
+    if p.packType == TypeLiteral {
+        return p.encodeLiteral(w)
+    }
+    if len(p.subPackets) == 0 || (p.packType >= TypeGreaterThan && len(p.subPackets) != 2) {
+        return fmt.Errorf("type %d packet can't have %d sub-packets", p.packType, len(p.subPackets))
+    }
+    if err := w.WriteBits(uint64(p.lengthType), 1); err != nil {
+        return fmt.Errorf("length type: %w", err)
+    }
+    if p.lengthType == LengthCount {
+        if err := w.WriteBits(uint64(len(p.subPackets)), 11); err != nil {
+            return fmt.Errorf("sub-packet count: %w", err)
+        }
+        for _, sub := range p.subPackets {
+            if err := sub.encode(w); err != nil {
+                return err
+            }
+        }
+        return nil
+    }
+    // the length in bits comes first, so encode the sub-packets on their own
+    // to find out what it is
+    subs := &BitWriter{}
+    for _, sub := range p.subPackets {
+        if err := sub.encode(subs); err != nil {
+            return err
+        }
+    }
+    if err := w.WriteBits(uint64(subs.Pos()), 15); err != nil {
+        return fmt.Errorf("sub-packet length: %w", err)
+    }
+    w.Append(subs)
+    return nil
+}
+
+func (p *Packet) encodeLiteral(w *BitWriter) error {
+    value := p.Literal()
+    if value.Sign() < 0 {
+        return fmt.Errorf("literal %v is negative", value)
+    }
+    nibbles := (value.BitLen() + 3) / 4
+    if nibbles == 0 {
+        nibbles = 1
+    }
+    for i := nibbles - 1; i >= 0; i-- {
+        group := new(big.Int).Rsh(value, uint(4 * i)).Uint64() & 0xF
+        if i > 0 {
+            group |= 0x10
+        }
+        w.WriteBits(group, 5)
+    }
+    return nil
+}
+
+// Equal compares two packet trees, ignoring where they sit in a transmission.
+func Equal(a, b *Packet) bool {
+    if a.version != b.version || a.packType != b.packType {
+        return false
+    }
+    if a.packType == TypeLiteral {
+        return a.Literal().Cmp(b.Literal()) == 0
+    }
+    if a.lengthType != b.lengthType || len(a.subPackets) != len(b.subPackets) {
+        return false
+    }
+    for i := range a.subPackets {
+        if ! Equal(a.subPackets[i], b.subPackets[i]) {
+            return false
+        }
+    }
+    return true
+}
+
+// randomPacket makes a random, valid packet tree no more than depth deep.
+// Some literals are well past 64 bits.
+func randomPacket(rng *rand.Rand, depth int) *Packet {
+    version := rng.Intn(8)
+    if depth <= 1 || rng.Intn(3) == 0 {
+        value := new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(rng.Intn(100) + 1)))
+        return NewLiteral(version, value)
+    }
+    packType := rng.Intn(7)
+    if packType >= TypeLiteral {
+        packType++
+    }
+    count := rng.Intn(4) + 1
+    if packType >= TypeGreaterThan {
+        count = 2
+    }
+    subs := make([]*Packet, count)
+    for i := range subs {
+        subs[i] = randomPacket(rng, depth - 1)
+    }
+    return NewOperator(version, packType, rng.Intn(2), subs...)
+}
+
+func roundtripTool(args []string) error {
+    fs := flag.NewFlagSet("roundtrip", flag.ContinueOnError)
+    count := fs.Int("count", 1000, "how many random packets to try")
+    depth := fs.Int("depth", 5, "how deeply to nest them")
+    seed := fs.Int64("seed", 0, "random seed; 0 picks one")
+    print := fs.Bool("print", false, "print each transmission and its value")
+    input := fs.String("input", "", "also re-encode the transmissions in this input")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+    if *input != "" {
+        if err := reencode(*input); err != nil {
+            return err
+        }
+    }
+    if *seed == 0 {
+        *seed = time.Now().UnixNano()
+    }
+    rng := rand.New(rand.NewSource(*seed))
+    for i := 0; i < *count; i++ {
+        packet := randomPacket(rng, *depth)
+        hex, err := Encode(packet)
+        if err != nil {
+            return fmt.Errorf("packet %d (seed %d): encoding %v: %w", i, *seed, packet, err)
+        }
+        decoded, err := Decode(hex)
+        if err != nil {
+            return fmt.Errorf("packet %d (seed %d): decoding %s: %w", i, *seed, hex, err)
+        }
+        if ! Equal(packet, decoded) {
+            return fmt.Errorf("packet %d (seed %d): %s decodes to %v, expected %v", i, *seed, hex, decoded, packet)
+        }
+        if *print {
+            fmt.Printf("%s %d %v\n", hex, decoded.VersionSum(), decoded.Resolve())
+        }
+    }
+    logger.Logs.Infof("%d random packets survived encoding and decoding (seed %d)", *count, *seed)
+    return nil
+}
+
+// reencode checks each transmission in an input encodes back to itself. The
+// puzzle pads with however many zeros it likes, so those don't count.
+func reencode(input string) error {
+    lines, err := reader.ReadLines(reader.SourceFor(16, input))
+    if err != nil {
+        return err
+    }
+    packets, err := packetsFromInput(lines)
+    if err != nil {
+        return err
+    }
+    trim := func(hex string) string {
+        return strings.TrimRight(strings.ToUpper(strings.TrimSpace(hex)), "0")
+    }
+    i := 0
+    for n, line := range lines {
+        if strings.TrimSpace(line) == "" {
+            continue
+        }
+        hex, err := Encode(packets[i])
+        if err != nil {
+            return fmt.Errorf("line %d: %w", n + 1, err)
+        }
+        if trim(hex) != trim(line) {
+            return fmt.Errorf("line %d: %s encodes back to %s", n + 1, strings.TrimSpace(line), hex)
+        }
+        i++
+    }
+    logger.Logs.Infof("%d transmissions in %s encode back to themselves", len(packets), input)
+    return nil
+}
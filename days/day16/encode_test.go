@@ -0,0 +1,127 @@
+package day16
+
+import (
+    "math/big"
+    "math/rand"
+    "strings"
+    "testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+    rng := rand.New(rand.NewSource(16))
+    // make sure the random packets cover what's awkward to encode
+    wide, lengthTypes := 0, make(map[int]int)
+    var walk func(p *Packet)
+    walk = func(p *Packet) {
+        if p.packType == TypeLiteral {
+            if p.Literal().BitLen() > 64 {
+                wide++
+            }
+            return
+        }
+        lengthTypes[p.lengthType]++
+        for _, sub := range p.subPackets {
+            walk(sub)
+        }
+    }
+    for i := 0; i < 2000; i++ {
+        packet := randomPacket(rng, 5)
+        walk(packet)
+        hex, err := Encode(packet)
+        if err != nil {
+            t.Fatalf("packet %d: encoding %v: %v", i, packet, err)
+        }
+        decoded, err := Decode(hex)
+        if err != nil {
+            t.Fatalf("packet %d: decoding %s: %v", i, hex, err)
+        }
+        if ! Equal(packet, decoded) {
+            t.Fatalf("packet %d: %s decodes to %v, expected %v", i, hex, decoded, packet)
+        }
+    }
+    if wide == 0 || lengthTypes[LengthBits] == 0 || lengthTypes[LengthCount] == 0 {
+        t.Errorf("random packets had %d literals over 64 bits and length types %v", wide, lengthTypes)
+    }
+}
+
+func TestEncodeLiteral(t *testing.T) {
+    value, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+    for _, literal := range []*big.Int{big.NewInt(0), big.NewInt(2021), value} {
+        hex, err := Encode(NewLiteral(7, literal))
+        if err != nil {
+            t.Fatal(err)
+        }
+        decoded, err := Decode(hex)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if decoded.Literal().Cmp(literal) != 0 || decoded.version != 7 {
+            t.Errorf("%v encoded as %s decodes to version %d, %v", literal, hex, decoded.version, decoded.Literal())
+        }
+    }
+}
+
+func TestEncodeErrors(t *testing.T) {
+    tooMany := make([]*Packet, 1 << 11)
+    for i := range tooMany {
+        tooMany[i] = NewLiteral(0, big.NewInt(1))
+    }
+    for name, packet := range map[string]*Packet{
+        "version": NewLiteral(8, big.NewInt(1)),
+        "no sub-packets": NewOperator(0, TypeSum, LengthCount),
+        "one-sided comparison": NewOperator(0, TypeLessThan, LengthBits, NewLiteral(0, big.NewInt(1))),
+        "sub-packet count": NewOperator(0, TypeSum, LengthCount, tooMany...),
+    } {
+        if hex, err := Encode(packet); err == nil {
+            t.Errorf("%s: encoded as %s, expected an error", name, hex)
+        }
+    }
+}
+
+// the puzzle's example transmissions
+var examples = []struct {
+    hex string
+    versionSum int // 0 where the puzzle doesn't say
+    value int64
+}{
+    {"D2FE28", 6, 2021},
+    {"38006F45291200", 9, 1},
+    {"EE00D40C823060", 14, 3},
+    {"8A004A801A8002F478", 16, 15},
+    {"620080001611562C8802118E34", 12, 46},
+    {"C0015000016115A2E0802F182340", 23, 46},
+    {"A0016C880162017C3686B18A3D4780", 31, 54},
+    {"C200B40A82", 0, 3},
+    {"04005AC33890", 0, 54},
+    {"880086C3E88112", 0, 7},
+    {"CE00C43D881120", 0, 9},
+    {"D8005AC2A8F0", 0, 1},
+    {"F600BC2D8F", 0, 0},
+    {"9C005AC2F8F0", 0, 0},
+    {"9C0141080250320F1802104A08", 20, 1},
+}
+
+func TestExamples(t *testing.T) {
+    trim := func(hex string) string {
+        return strings.TrimRight(hex, "0")
+    }
+    for _, example := range examples {
+        packet, err := Decode(example.hex)
+        if err != nil {
+            t.Errorf("%s: %v", example.hex, err)
+            continue
+        }
+        if example.versionSum != 0 && packet.VersionSum() != example.versionSum {
+            t.Errorf("%s: version sum %d, want %d", example.hex, packet.VersionSum(), example.versionSum)
+        }
+        if value := packet.Resolve(); value.Cmp(big.NewInt(example.value)) != 0 {
+            t.Errorf("%s: value %v, want %d", example.hex, value, example.value)
+        }
+        hex, err := Encode(packet)
+        if err != nil {
+            t.Errorf("%s: encoding: %v", example.hex, err)
+        } else if trim(hex) != trim(example.hex) {
+            t.Errorf("%s encodes back to %s", example.hex, hex)
+        }
+    }
+}
@@ -1,13 +1,14 @@
-package main
+package day17
 
     // "sort"
     // "strings"
 import (
     "fmt"
     "regexp"
-    "strconv"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 type Area struct {
@@ -18,16 +19,19 @@ func (a Area) String() string {
     return fmt.Sprintf("%d < x < %d, %d < y < %d", a.minX, a.maxX, a.minY, a.maxY)
 }
 
-func targetArea(lines []string) Area {
-    reg := regexp.MustCompile(`target area: x=([0-9-]+)..([0-9-]+), y=([0-9-]+)..([0-9-]+)`)
-    result := reg.FindStringSubmatch(lines[0])
-    results := make([]int, 0)
-    for i := 1; i < 5; i++ {
-        tmp, _ := strconv.Atoi(result[i])
-        results = append(results, tmp)
+var areaReg = regexp.MustCompile(`target area: x=(?P<minX>-?\d+)\.\.(?P<maxX>-?\d+), y=(?P<minY>-?\d+)\.\.(?P<maxY>-?\d+)`)
+
+func targetArea(lines []string) (Area, error) {
+    if len(lines) == 0 {
+        return Area{}, fmt.Errorf("no target area in input")
+    }
+    var bounds struct {
+        MinX, MaxX, MinY, MaxY int
     }
-    area := Area{results[0], results[1], results[2], results[3]}
-    return area
+    if err := parser.Match(areaReg, lines[0], &bounds); err != nil {
+        return Area{}, &parser.LineError{Line: 1, Err: err}
+    }
+    return Area{bounds.MinX, bounds.MaxX, bounds.MinY, bounds.MaxY}, nil
 }
 
 func Sigma(n int) int {
@@ -114,26 +118,35 @@ func xyVelInArea(a Area, x, y int) bool {
     }
 }
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
+func init() {
+    runner.Register(17, part1, part2)
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
-    area := targetArea(lines)
-    logger.Logs.Infof("Got area: %v", area)
-    logger.Logs.Infof("Minimal x-velocity to reach target: %d", minXVelocity(area))
-    logger.Logs.Infof("Maximal y-velocity to reach target: %d", maxYVelocity(area))
-    return Sigma(maxYVelocity(area))
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    area, err := targetArea(lines)
+    if err != nil {
+        return 0, err
+    }
+    logs.Infof("Got area: %v", area)
+    logs.Infof("Minimal x-velocity to reach target: %d", minXVelocity(area))
+    logs.Infof("Maximal y-velocity to reach target: %d", maxYVelocity(area))
+    return Sigma(maxYVelocity(area)), nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    area := targetArea(lines)
-    logger.Logs.Infof("Got area: %v", area)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    area, err := targetArea(lines)
+    if err != nil {
+        return 0, err
+    }
+    logs.Infof("Got area: %v", area)
     minXVel := minXVelocity(area)
     maxXVel := area.maxX
     minYVel := area.minY
@@ -146,6 +159,6 @@ func part2() int {
             }
         }
     }
-    return count
+    return count, nil
 }
 
@@ -0,0 +1,79 @@
+package day18
+
+import (
+    "fmt"
+    "runtime"
+    "strings"
+    logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// numbersFromInput returns the numbers and which line each was on.
+func numbersFromInput(lines []string) ([]*Number, []int, error) {
+    numbers := make([]*Number, 0)
+    lineNumbers := make([]int, 0)
+    for i, line := range lines {
+        line = strings.TrimSpace(line)
+        if line == "" {
+            continue
+        }
+        number, err := Parse(line)
+        if err != nil {
+            return nil, nil, &parser.LineError{Line: i + 1, Err: err}
+        }
+        number.Reduce()
+        numbers = append(numbers, number)
+        lineNumbers = append(lineNumbers, i + 1)
+    }
+    if len(numbers) == 0 {
+        return nil, nil, fmt.Errorf("no snailfish numbers in the input")
+    }
+    return numbers, lineNumbers, nil
+}
+
+func init() {
+    runner.Register(18, part1, part2)
+    runner.RegisterTool(18, runner.Tool{
+        Name: "pairs",
+        Summary: "find which two lines add up to the largest magnitude, and trace a sum's reduction",
+        Run: pairsTool,
+    })
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    numbers, _, err := numbersFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    sum := numbers[0]
+    for _, number := range numbers[1:] {
+        sum = Add(sum, number)
+    }
+    return sum.Magnitude(), nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    numbers, lineNumbers, err := numbersFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    if len(numbers) < 2 {
+        return 0, fmt.Errorf("need at least two numbers to add, got %d", len(numbers))
+    }
+    best := BestPair(numbers, runtime.NumCPU())
+    logs.WithFields(logger.Fields{
+        "line_a": lineNumbers[best.A],
+        "line_b": lineNumbers[best.B],
+    }).Infof("Lines %d and %d add up to the largest magnitude", lineNumbers[best.A], lineNumbers[best.B])
+    return best.Magnitude, nil
+}
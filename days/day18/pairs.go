@@ -0,0 +1,142 @@
+package day18
+
+import (
+    "flag"
+    "fmt"
+    "runtime"
+    "strconv"
+    "strings"
+    "sync"
+    reader "advent2021/adventreader"
+)
+
+// PairResult is the sum of numbers[A] + numbers[B].
+type PairResult struct {
+    A, B int
+    Magnitude int
+}
+
+// better is how pair results are ranked: largest magnitude first, then the
+// earliest pair, so the answer doesn't depend on which worker got there first.
+func (r PairResult) better(than PairResult) bool {
+    if r.Magnitude != than.Magnitude {
+        return r.Magnitude > than.Magnitude
+    }
+    if r.A != than.A {
+        return r.A < than.A
+    }
+    return r.B < than.B
+}
+
+// BestPair adds every ordered pair of different numbers and returns the one
+// with the largest magnitude. Each worker takes every sum with a given left
+// hand side at a time. There must be at least two numbers.
+func BestPair(numbers []*Number, workers int) PairResult {
+    if workers < 1 {
+        workers = 1
+    }
+    rows := make(chan int)
+    results := make(chan PairResult, workers)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            best := PairResult{Magnitude: -1}
+            for a := range rows {
+                for b := range numbers {
+                    if a == b {
+                        continue
+                    }
+                    result := PairResult{A: a, B: b, Magnitude: Add(numbers[a], numbers[b]).Magnitude()}
+                    if result.better(best) {
+                        best = result
+                    }
+                }
+            }
+            results <- best
+        }()
+    }
+    for a := range numbers {
+        rows <- a
+    }
+    close(rows)
+    wg.Wait()
+    close(results)
+    best := PairResult{Magnitude: -1}
+    for result := range results {
+        if result.better(best) {
+            best = result
+        }
+    }
+    return best
+}
+
+func pairsTool(args []string) error {
+    fs := flag.NewFlagSet("pairs", flag.ContinueOnError)
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    workers := fs.Int("workers", runtime.NumCPU(), "how many sums to work on at once")
+    trace := fs.String("trace", "", "line numbers A,B: show every step of reducing A + B instead")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+    lines, err := reader.ReadLines(reader.SourceFor(18, *input))
+    if err != nil {
+        return err
+    }
+    numbers, lineNumbers, err := numbersFromInput(lines)
+    if err != nil {
+        return err
+    }
+    if *trace != "" {
+        a, b, err := traceLines(*trace, lineNumbers)
+        if err != nil {
+            return err
+        }
+        traceSum(numbers[a], numbers[b])
+        return nil
+    }
+    if len(numbers) < 2 {
+        return fmt.Errorf("need at least two numbers to add, got %d", len(numbers))
+    }
+    best := BestPair(numbers, *workers)
+    fmt.Printf("line %d: %v\n", lineNumbers[best.A], numbers[best.A])
+    fmt.Printf("line %d: %v\n", lineNumbers[best.B], numbers[best.B])
+    fmt.Printf("sum: %v\n", Add(numbers[best.A], numbers[best.B]))
+    fmt.Printf("magnitude: %d\n", best.Magnitude)
+    return nil
+}
+
+// traceLines turns "A,B" input line numbers into indexes of the numbers.
+func traceLines(arg string, lineNumbers []int) (int, int, error) {
+    fields := strings.Split(arg, ",")
+    if len(fields) != 2 {
+        return 0, 0, fmt.Errorf("--trace wants two line numbers like 3,9, got %q", arg)
+    }
+    indexes := make([]int, 2)
+    for i, field := range fields {
+        line, err := strconv.Atoi(strings.TrimSpace(field))
+        if err != nil {
+            return 0, 0, fmt.Errorf("--trace: %w", err)
+        }
+        indexes[i] = -1
+        for j, n := range lineNumbers {
+            if n == line {
+                indexes[i] = j
+            }
+        }
+        if indexes[i] < 0 {
+            return 0, 0, fmt.Errorf("--trace: no snailfish number on line %d", line)
+        }
+    }
+    return indexes[0], indexes[1], nil
+}
+
+func traceSum(a, b *Number) {
+    sum := Pair(a.Clone(), b.Clone())
+    fmt.Printf("%-8s %v\n", "add", sum)
+    sum.ReduceTrace(func(step string) {
+        fmt.Printf("%-8s %v\n", step, sum)
+    })
+    fmt.Printf("magnitude: %d\n", sum.Magnitude())
+}
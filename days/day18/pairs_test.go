@@ -0,0 +1,51 @@
+package day18
+
+import (
+    "testing"
+    reader "advent2021/adventreader"
+)
+
+func TestBestPair(t *testing.T) {
+    lines, err := reader.ReadLines(reader.SourceFor(18, "./test.txt"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    numbers, lineNumbers, err := numbersFromInput(lines)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, workers := range []int{1, 8} {
+        best := BestPair(numbers, workers)
+        if lineNumbers[best.A] != 9 || lineNumbers[best.B] != 1 || best.Magnitude != 3993 {
+            t.Errorf("%d workers: lines %d and %d give %d, expected lines 9 and 1 giving 3993", workers, lineNumbers[best.A], lineNumbers[best.B], best.Magnitude)
+        }
+    }
+}
+
+func TestBestPairTies(t *testing.T) {
+    // every pair of these adds up to the same thing, so the first pair wins
+    numbers := make([]*Number, 6)
+    for i := range numbers {
+        numbers[i] = mustParse(t, "[[1,2],[3,4]]")
+    }
+    for _, workers := range []int{0, 1, 3, 8} {
+        if best := BestPair(numbers, workers); best.A != 0 || best.B != 1 {
+            t.Errorf("%d workers: picked %d and %d, expected 0 and 1", workers, best.A, best.B)
+        }
+    }
+    tests := []struct {
+        r, than PairResult
+        better bool
+    }{
+        {PairResult{A: 5, B: 6, Magnitude: 10}, PairResult{A: 0, B: 1, Magnitude: 9}, true},
+        {PairResult{A: 0, B: 1, Magnitude: 9}, PairResult{A: 5, B: 6, Magnitude: 10}, false},
+        {PairResult{A: 1, B: 6, Magnitude: 10}, PairResult{A: 2, B: 0, Magnitude: 10}, true},
+        {PairResult{A: 1, B: 2, Magnitude: 10}, PairResult{A: 1, B: 3, Magnitude: 10}, true},
+        {PairResult{A: 1, B: 3, Magnitude: 10}, PairResult{A: 1, B: 3, Magnitude: 10}, false},
+    }
+    for _, test := range tests {
+        if got := test.r.better(test.than); got != test.better {
+            t.Errorf("%+v better than %+v is %v, expected %v", test.r, test.than, got, test.better)
+        }
+    }
+}
@@ -0,0 +1,174 @@
+package day18
+
+import (
+    "fmt"
+    "strconv"
+)
+
+// Number is a snailfish number: either a regular number (a leaf, with no
+// children) or a pair of snailfish numbers.
+type Number struct {
+    value int
+    left, right *Number
+}
+
+func Regular(value int) *Number {
+    return &Number{value: value}
+}
+
+func Pair(left, right *Number) *Number {
+    return &Number{left: left, right: right}
+}
+
+func (n *Number) IsRegular() bool {
+    return n.left == nil
+}
+
+func (n *Number) String() string {
+    if n.IsRegular() {
+        return strconv.Itoa(n.value)
+    }
+    return "[" + n.left.String() + "," + n.right.String() + "]"
+}
+
+func (n *Number) Clone() *Number {
+    if n.IsRegular() {
+        return Regular(n.value)
+    }
+    return Pair(n.left.Clone(), n.right.Clone())
+}
+
+// Parse reads a number written the way the puzzle does, like [[1,2],3].
+func Parse(s string) (*Number, error) {
+    n, pos, err := parse(s, 0)
+    if err != nil {
+        return nil, err
+    }
+    if pos != len(s) {
+        return nil, fmt.Errorf("column %d: unexpected %q after the number", pos + 1, s[pos:])
+    }
+    return n, nil
+}
+
+// parse reads the number starting at pos and returns where it ended.
+func parse(s string, pos int) (*Number, int, error) {
+    if pos >= len(s) {
+        return nil, pos, fmt.Errorf("column %d: expected a number, got the end of the line", pos + 1)
+    }
+    if s[pos] != '[' {
+        end := pos
+        for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+            end++
+        }
+        if end == pos {
+            return nil, pos, fmt.Errorf("column %d: expected a number, got %q", pos + 1, s[pos])
+        }
+        value, err := strconv.Atoi(s[pos:end])
+        if err != nil {
+            return nil, pos, fmt.Errorf("column %d: %w", pos + 1, err)
+        }
+        return Regular(value), end, nil
+    }
+    left, pos, err := parse(s, pos + 1)
+    if err != nil {
+        return nil, pos, err
+    }
+    if pos >= len(s) || s[pos] != ',' {
+        return nil, pos, fmt.Errorf("column %d: expected ','", pos + 1)
+    }
+    right, pos, err := parse(s, pos + 1)
+    if err != nil {
+        return nil, pos, err
+    }
+    if pos >= len(s) || s[pos] != ']' {
+        return nil, pos, fmt.Errorf("column %d: expected ']'", pos + 1)
+    }
+    return Pair(left, right), pos + 1, nil
+}
+
+// Add pairs up two numbers and reduces the result. Neither a nor b is
+// changed.
+func Add(a, b *Number) *Number {
+    sum := Pair(a.Clone(), b.Clone())
+    sum.Reduce()
+    return sum
+}
+
+// Explode explodes the leftmost pair nested inside four others, if there is
+// one: its left value goes to the nearest regular number on its left, its
+// right value to the nearest on its right, and it becomes 0.
+func (n *Number) Explode() bool {
+    var previous *Number // the last regular number we walked past
+    exploded := false
+    carry := 0
+    var walk func(n *Number, depth int) bool
+    walk = func(n *Number, depth int) bool {
+        if n.IsRegular() {
+            if exploded {
+                n.value += carry
+                return true
+            }
+            previous = n
+            return false
+        }
+        if ! exploded && depth >= 4 && n.left.IsRegular() && n.right.IsRegular() {
+            if previous != nil {
+                previous.value += n.left.value
+            }
+            carry = n.right.value
+            n.value, n.left, n.right = 0, nil, nil
+            exploded = true
+            return false
+        }
+        return walk(n.left, depth + 1) || walk(n.right, depth + 1)
+    }
+    walk(n, 0)
+    return exploded
+}
+
+// Split splits the leftmost regular number of 10 or more into a pair, halved
+// rounding down on the left and up on the right.
+func (n *Number) Split() bool {
+    if n.IsRegular() {
+        if n.value < 10 {
+            return false
+        }
+        n.left, n.right = Regular(n.value / 2), Regular((n.value + 1) / 2)
+        n.value = 0
+        return true
+    }
+    return n.left.Split() || n.right.Split()
+}
+
+// Step does one step of reduction, exploding if anything can explode and
+// otherwise splitting. It returns false once the number is reduced.
+func (n *Number) Step() bool {
+    return n.Explode() || n.Split()
+}
+
+func (n *Number) Reduce() {
+    for n.Step() {
+    }
+}
+
+// ReduceTrace is Reduce, calling trace with "explode" or "split" after each
+// step.
+func (n *Number) ReduceTrace(trace func(step string)) {
+    for {
+        switch {
+        case n.Explode():
+            trace("explode")
+        case n.Split():
+            trace("split")
+        default:
+            return
+        }
+    }
+}
+
+func (n *Number) Magnitude() int {
+    if n.IsRegular() {
+        return n.value
+    }
+    return 3 * n.left.Magnitude() + 2 * n.right.Magnitude()
+}
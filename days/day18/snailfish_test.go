@@ -0,0 +1,128 @@
+package day18
+
+import (
+    "testing"
+)
+
+func mustParse(t *testing.T, s string) *Number {
+    t.Helper()
+    n, err := Parse(s)
+    if err != nil {
+        t.Fatalf("parsing %s: %v", s, err)
+    }
+    return n
+}
+
+func TestExplode(t *testing.T) {
+    // the puzzle's examples
+    tests := []struct {
+        before, after string
+    }{
+        {"[[[[[9,8],1],2],3],4]", "[[[[0,9],2],3],4]"},
+        {"[7,[6,[5,[4,[3,2]]]]]", "[7,[6,[5,[7,0]]]]"},
+        {"[[6,[5,[4,[3,2]]]],1]", "[[6,[5,[7,0]]],3]"},
+        {"[[3,[2,[1,[7,3]]]],[6,[5,[4,[3,2]]]]]", "[[3,[2,[8,0]]],[9,[5,[4,[3,2]]]]]"},
+        {"[[3,[2,[8,0]]],[9,[5,[4,[3,2]]]]]", "[[3,[2,[8,0]]],[9,[5,[7,0]]]]"},
+    }
+    for _, test := range tests {
+        n := mustParse(t, test.before)
+        if ! n.Explode() {
+            t.Errorf("%s didn't explode", test.before)
+        } else if n.String() != test.after {
+            t.Errorf("%s exploded into %s, expected %s", test.before, n, test.after)
+        }
+    }
+    if n := mustParse(t, "[[[[0,9],2],3],4]"); n.Explode() {
+        t.Errorf("nothing is nested deep enough to explode, but got %s", n)
+    }
+}
+
+func TestSplit(t *testing.T) {
+    tests := []struct {
+        before, after string
+    }{
+        {"10", "[5,5]"},
+        {"11", "[5,6]"},
+        {"12", "[6,6]"},
+        {"[[[[0,7],4],[15,[0,13]]],[1,1]]", "[[[[0,7],4],[[7,8],[0,13]]],[1,1]]"},
+        {"[[[[0,7],4],[[7,8],[0,13]]],[1,1]]", "[[[[0,7],4],[[7,8],[0,[6,7]]]],[1,1]]"},
+    }
+    for _, test := range tests {
+        n := mustParse(t, test.before)
+        if ! n.Split() {
+            t.Errorf("%s didn't split", test.before)
+        } else if n.String() != test.after {
+            t.Errorf("%s split into %s, expected %s", test.before, n, test.after)
+        }
+    }
+    if n := mustParse(t, "[9,[1,9]]"); n.Split() {
+        t.Errorf("nothing is 10 or more, but got %s", n)
+    }
+}
+
+func TestReduceTrace(t *testing.T) {
+    // the worked example of adding [[[[4,3],4],4],[7,[[8,4],9]]] and [1,1]
+    sum := Pair(mustParse(t, "[[[[4,3],4],4],[7,[[8,4],9]]]"), mustParse(t, "[1,1]"))
+    steps := make([]string, 0)
+    sum.ReduceTrace(func(step string) {
+        steps = append(steps, step + " " + sum.String())
+    })
+    want := []string{
+        "explode [[[[0,7],4],[7,[[8,4],9]]],[1,1]]",
+        "explode [[[[0,7],4],[15,[0,13]]],[1,1]]",
+        "split [[[[0,7],4],[[7,8],[0,13]]],[1,1]]",
+        "split [[[[0,7],4],[[7,8],[0,[6,7]]]],[1,1]]",
+        "explode [[[[0,7],4],[[7,8],[6,0]]],[8,1]]",
+    }
+    if len(steps) != len(want) {
+        t.Fatalf("took %d steps, expected %d: %v", len(steps), len(want), steps)
+    }
+    for i := range want {
+        if steps[i] != want[i] {
+            t.Errorf("step %d: got %s, expected %s", i + 1, steps[i], want[i])
+        }
+    }
+}
+
+func TestParseString(t *testing.T) {
+    for _, s := range []string{
+        "7",
+        "[1,2]",
+        "[[1,2],3]",
+        "[[[[1,3],[5,3]],[[1,3],[8,7]]],[[[4,9],[6,9]],[[8,2],[7,3]]]]",
+        "[[[[0,7],4],[15,[0,13]]],[1,1]]",
+    } {
+        if got := mustParse(t, s).String(); got != s {
+            t.Errorf("%s came back as %s", s, got)
+        }
+    }
+    for _, s := range []string{"", "[1,2", "[1 2]", "[1,2]]", "[,2]", "[1,[2]]"} {
+        if n, err := Parse(s); err == nil {
+            t.Errorf("%q parsed as %s, expected an error", s, n)
+        }
+    }
+}
+
+func TestAddMagnitude(t *testing.T) {
+    a, b := mustParse(t, "[[[[4,3],4],4],[7,[[8,4],9]]]"), mustParse(t, "[1,1]")
+    sum := Add(a, b)
+    if sum.String() != "[[[[0,7],4],[[7,8],[6,0]]],[8,1]]" {
+        t.Errorf("sum is %s", sum)
+    }
+    if a.String() != "[[[[4,3],4],4],[7,[[8,4],9]]]" || b.String() != "[1,1]" {
+        t.Errorf("Add changed its arguments to %s and %s", a, b)
+    }
+    tests := []struct {
+        n string
+        magnitude int
+    }{
+        {"[[1,2],[[3,4],5]]", 143},
+        {"[[[[0,7],4],[[7,8],[6,0]]],[8,1]]", 1384},
+        {"[[[[8,7],[7,7]],[[8,6],[7,7]]],[[[0,7],[6,6]],[8,7]]]", 3488},
+    }
+    for _, test := range tests {
+        if got := mustParse(t, test.n).Magnitude(); got != test.magnitude {
+            t.Errorf("magnitude of %s is %d, expected %d", test.n, got, test.magnitude)
+        }
+    }
+}
@@ -0,0 +1,263 @@
+package day19
+
+import (
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    logger "advent2021/adventlogger"
+)
+
+// scanners that see the same 12 beacons share the 66 distances between them
+const minShared = 12
+const minSharedDistances = minShared * (minShared - 1) / 2
+
+// Rotation is a rotation matrix. A scanner can be facing any of 6 ways with
+// any of 4 ways up, which Rotations lists.
+type Rotation [3][3]int
+
+var Identity = Rotation{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+var Rotations = rotations()
+
+// rotations makes every matrix that maps each axis onto a different one,
+// either way round, without mirroring anything.
+func rotations() []Rotation {
+    axes := [][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+    all := make([]Rotation, 0, 24)
+    for _, axis := range axes {
+        for signs := 0; signs < 8; signs++ {
+            var r Rotation
+            for row := 0; row < 3; row++ {
+                r[row][axis[row]] = 1
+                if signs >> row & 1 == 1 {
+                    r[row][axis[row]] = -1
+                }
+            }
+            if r.det() == 1 {
+                all = append(all, r)
+            }
+        }
+    }
+    return all
+}
+
+func (r Rotation) det() int {
+    return r[0][0] * (r[1][1] * r[2][2] - r[1][2] * r[2][1]) -
+        r[0][1] * (r[1][0] * r[2][2] - r[1][2] * r[2][0]) +
+        r[0][2] * (r[1][0] * r[2][1] - r[1][1] * r[2][0])
+}
+
+func (r Rotation) Apply(p Point) Point {
+    return Point{
+        x: r[0][0] * p.x + r[0][1] * p.y + r[0][2] * p.z,
+        y: r[1][0] * p.x + r[1][1] * p.y + r[1][2] * p.z,
+        z: r[2][0] * p.x + r[2][1] * p.y + r[2][2] * p.z,
+    }
+}
+
+// fingerprint is the sorted squared distances between every pair of a
+// scanner's beacons, which don't change however the scanner is turned.
+func fingerprint(points []Point) []int {
+    distances := make([]int, 0, len(points) * (len(points) - 1) / 2)
+    for i := range points {
+        for j := i + 1; j < len(points); j++ {
+            d := vector(points[i], points[j])
+            distances = append(distances, d.x * d.x + d.y * d.y + d.z * d.z)
+        }
+    }
+    sort.Ints(distances)
+    return distances
+}
+
+// shared counts the distances two fingerprints have in common.
+func shared(a, b []int) int {
+    count := 0
+    for i, j := 0, 0; i < len(a) && j < len(b); {
+        switch {
+        case a[i] < b[j]:
+            i++
+        case a[i] > b[j]:
+            j++
+        default:
+            count++
+            i++
+            j++
+        }
+    }
+    return count
+}
+
+// align looks for a rotation and position for s that puts at least minShared
+// of its beacons on top of beacons we've already placed. For each rotation,
+// every pairing of one of s's beacons with a placed one votes for the
+// position that would line them up.
+func align(placed []Point, s *Scanner) (Rotation, Point, bool) {
+    for _, r := range Rotations {
+        votes := make(map[Point]int)
+        for _, p := range s.points {
+            rotated := r.Apply(p)
+            for _, q := range placed {
+                position := vector(rotated, q)
+                votes[position]++
+                if votes[position] >= minShared {
+                    return r, position, true
+                }
+            }
+        }
+    }
+    return Rotation{}, Point{}, false
+}
+
+// Placement is where a scanner turned out to be, relative to the first one:
+// turn its readings by Rotation and add Position to get them the same way.
+type Placement struct {
+    Label string `json:"label"`
+    Rotation Rotation `json:"rotation"`
+    Position Point `json:"position"`
+    beacons []Point // the scanner's beacons, relative to the first scanner
+}
+
+// Result is every scanner placed, in input order, and every beacon any of
+// them can see, sorted.
+type Result struct {
+    Scanners []Placement `json:"scanners"`
+    Beacons []Point `json:"beacons"`
+}
+
+// MaxDistance is the largest Manhattan distance between two scanners.
+func (r *Result) MaxDistance() int {
+    max := 0
+    for i, a := range r.Scanners {
+        for _, b := range r.Scanners[i + 1:] {
+            if d := manhattan(a.Position, b.Position); d > max {
+                max = d
+            }
+        }
+    }
+    return max
+}
+
+// labelLess orders scanner labels as numbers when they are numbers.
+func labelLess(a, b string) bool {
+    an, aErr := strconv.Atoi(a)
+    bn, bErr := strconv.Atoi(b)
+    if aErr == nil && bErr == nil {
+        return an < bn
+    }
+    return a < b
+}
+
+// attempt is one try at aligning scanner with the already placed ref.
+type attempt struct {
+    scanner, ref int
+    rotation Rotation
+    position Point
+    ok bool
+}
+
+// Align places every scanner relative to the first. It goes in rounds: every
+// scanner still to place is tried against every scanner placed last round
+// whose fingerprint says they could overlap, spread over workers goroutines.
+// When a scanner lines up with more than one, the lowest label wins, so the
+// result doesn't depend on which attempt finished first.
+func Align(scanners []*Scanner, workers int, logs *logger.Logger) (*Result, error) {
+    if workers < 1 {
+        workers = 1
+    }
+    fingerprints := make([][]int, len(scanners))
+    for i, s := range scanners {
+        fingerprints[i] = fingerprint(s.points)
+    }
+    byLabel := func(indexes []int) {
+        sort.Slice(indexes, func(i, j int) bool {
+            return labelLess(scanners[indexes[i]].label, scanners[indexes[j]].label)
+        })
+    }
+    placements := make([]*Placement, len(scanners))
+    placements[0] = &Placement{Label: scanners[0].label, Rotation: Identity, beacons: scanners[0].points}
+    frontier := []int{0}
+    for len(frontier) > 0 {
+        unplaced := make([]int, 0)
+        for i := range scanners {
+            if placements[i] == nil {
+                unplaced = append(unplaced, i)
+            }
+        }
+        byLabel(unplaced)
+        attempts := make([]attempt, 0)
+        for _, i := range unplaced {
+            for _, ref := range frontier {
+                if shared(fingerprints[ref], fingerprints[i]) >= minSharedDistances {
+                    attempts = append(attempts, attempt{scanner: i, ref: ref})
+                }
+            }
+        }
+        jobs := make(chan int)
+        var wg sync.WaitGroup
+        for w := 0; w < workers; w++ {
+            wg.Add(1)
+            go func() {
+                defer wg.Done()
+                for j := range jobs {
+                    a := &attempts[j]
+                    a.rotation, a.position, a.ok = align(placements[a.ref].beacons, scanners[a.scanner])
+                }
+            }()
+        }
+        for j := range attempts {
+            jobs <- j
+        }
+        close(jobs)
+        wg.Wait()
+        // attempts are in label order of scanner, then of ref, so the first
+        // success for each scanner is the one we want
+        frontier = make([]int, 0)
+        for _, a := range attempts {
+            if ! a.ok || placements[a.scanner] != nil {
+                continue
+            }
+            s := scanners[a.scanner]
+            beacons := make([]Point, len(s.points))
+            for j, p := range s.points {
+                rotated := a.rotation.Apply(p)
+                beacons[j] = Point{x: rotated.x + a.position.x, y: rotated.y + a.position.y, z: rotated.z + a.position.z}
+            }
+            placements[a.scanner] = &Placement{Label: s.label, Rotation: a.rotation, Position: a.position, beacons: beacons}
+            logs.WithField("scanner", s.label).Tracef("Scanner with label %s has origin at %v", s.label, a.position)
+            frontier = append(frontier, a.scanner)
+        }
+    }
+    missing := make([]string, 0)
+    for i, p := range placements {
+        if p == nil {
+            missing = append(missing, scanners[i].label)
+        }
+    }
+    if len(missing) > 0 {
+        return nil, fmt.Errorf("couldn't line up scanners %s with the rest", strings.Join(missing, ", "))
+    }
+    result := &Result{Scanners: make([]Placement, len(placements)), Beacons: make([]Point, 0)}
+    seen := make(map[Point]bool)
+    for i, p := range placements {
+        result.Scanners[i] = *p
+        for _, beacon := range p.beacons {
+            if ! seen[beacon] {
+                seen[beacon] = true
+                result.Beacons = append(result.Beacons, beacon)
+            }
+        }
+    }
+    sort.Slice(result.Beacons, func(i, j int) bool {
+        a, b := result.Beacons[i], result.Beacons[j]
+        if a.x != b.x {
+            return a.x < b.x
+        }
+        if a.y != b.y {
+            return a.y < b.y
+        }
+        return a.z < b.z
+    })
+    return result, nil
+}
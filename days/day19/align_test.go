@@ -0,0 +1,97 @@
+package day19
+
+import (
+    "io"
+    "reflect"
+    "testing"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    "github.com/sirupsen/logrus"
+)
+
+func exampleScanners(t *testing.T) []*Scanner {
+    t.Helper()
+    lines, err := reader.ReadLines(reader.SourceFor(19, "./test.txt"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    scanners, err := scannersFromInput(lines)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return scanners
+}
+
+func TestRotations(t *testing.T) {
+    if len(Rotations) != 24 {
+        t.Errorf("got %d rotations, expected 24", len(Rotations))
+    }
+    seen := make(map[Rotation]bool)
+    for _, r := range Rotations {
+        if seen[r] {
+            t.Errorf("%v is in Rotations twice", r)
+        }
+        seen[r] = true
+        if r.det() != 1 {
+            t.Errorf("%v has determinant %d, expected 1", r, r.det())
+        }
+    }
+    if ! seen[Identity] {
+        t.Errorf("Rotations is missing the identity")
+    }
+    // turning a point that's different on every axis gives 24 different points
+    p := Point{1, 2, 3}
+    turned := make(map[Point]bool)
+    for _, r := range Rotations {
+        turned[r.Apply(p)] = true
+    }
+    if len(turned) != 24 {
+        t.Errorf("%v turns into %d different points, expected 24", p, len(turned))
+    }
+}
+
+func TestFingerprintFilter(t *testing.T) {
+    scanners := exampleScanners(t)
+    // the puzzle says which scanners see the same beacons
+    overlapping := map[[2]int]bool{{0, 1}: true, {1, 3}: true, {1, 4}: true, {2, 4}: true}
+    for i := range scanners {
+        for j := i + 1; j < len(scanners); j++ {
+            count := shared(fingerprint(scanners[i].points), fingerprint(scanners[j].points))
+            overlap := overlapping[[2]int{i, j}]
+            if (count >= minSharedDistances) != overlap {
+                t.Errorf("scanners %d and %d share %d distances, but overlapping is %v", i, j, count, overlap)
+            }
+            // and the filter agrees with actually trying to line them up
+            if _, _, ok := align(scanners[i].points, scanners[j]); ok != overlap {
+                t.Errorf("scanners %d and %d line up is %v, expected %v", i, j, ok, overlap)
+            }
+        }
+    }
+}
+
+func TestAlignWorkers(t *testing.T) {
+    quiet := logger.GetLoggers(io.Discard, io.Discard, io.Discard, io.Discard, &logrus.TextFormatter{}, logrus.InfoLevel)
+    one, err := Align(exampleScanners(t), 1, quiet)
+    if err != nil {
+        t.Fatal(err)
+    }
+    positions := []Point{{0, 0, 0}, {68, -1246, -43}, {1105, -1205, 1229}, {-92, -2380, -20}, {-20, -1133, 1061}}
+    for i, s := range one.Scanners {
+        if s.Position != positions[i] {
+            t.Errorf("scanner %s is at %v, expected %v", s.Label, s.Position, positions[i])
+        }
+    }
+    if len(one.Beacons) != 79 {
+        t.Errorf("found %d beacons, expected 79", len(one.Beacons))
+    }
+    // however the attempts get shared out, the same ones win
+    for run := 0; run < 5; run++ {
+        eight, err := Align(exampleScanners(t), 8, quiet)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if ! reflect.DeepEqual(one, eight) {
+            t.Fatalf("8 workers placed the scanners at\n%+v\nbut 1 worker placed them at\n%+v", eight.Scanners, one.Scanners)
+        }
+    }
+}
@@ -0,0 +1,121 @@
+package day19
+
+import (
+    "fmt"
+    "regexp"
+    "runtime"
+    logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+type Point struct {
+    x, y, z int
+}
+
+func (p Point) String() string {
+    return fmt.Sprintf("%d,%d,%d", p.x, p.y, p.z)
+}
+
+// MarshalJSON writes a point as [x, y, z].
+func (p Point) MarshalJSON() ([]byte, error) {
+    return []byte(fmt.Sprintf("[%d,%d,%d]", p.x, p.y, p.z)), nil
+}
+
+func abs(n int) int {
+    if n < 0 {
+        return -n
+    }
+    return n
+}
+
+func manhattan(a, b Point) int {
+    return abs(a.x - b.x) + abs(a.y - b.y) + abs(a.z - b.z)
+}
+
+func vector(a, b Point) Point {
+    return Point{x: b.x - a.x, y: b.y - a.y, z: b.z - a.z}
+}
+
+type Scanner struct {
+    points []Point
+    label string
+}
+
+func NewScanner(label string) *Scanner {
+    return &Scanner{points: make([]Point, 0), label: label}
+}
+
+func (s Scanner) String() string {
+    return fmt.Sprintf("%s, Points: %s", s.label, fmt.Sprint(s.points))
+}
+
+var scannerReg = regexp.MustCompile(`^---\sscanner\s(?P<label>\d+)\s---$`)
+
+func scannersFromInput(lines []string) ([]*Scanner, error) {
+    scanners := make([]*Scanner, 0)
+    for _, block := range parser.Blocks(lines) {
+        var header struct {
+            Label string
+        }
+        if err := parser.Match(scannerReg, block.Lines[0], &header); err != nil {
+            return nil, &parser.LineError{Line: block.Line, Err: err}
+        }
+        scanner := NewScanner(header.Label)
+        beacons := parser.Block{Line: block.Line + 1, Lines: block.Lines[1:]}
+        coords, err := parser.IntRows(beacons.Lines, ",")
+        if err != nil {
+            return nil, beacons.Err(err)
+        }
+        for i, xyz := range coords {
+            if len(xyz) != 3 {
+                return nil, &parser.LineError{Line: beacons.Line + i, Err: fmt.Errorf("expected x,y,z, got %d coordinates", len(xyz))}
+            }
+            p := Point{x: xyz[0], y: xyz[1], z: xyz[2]}
+            scanner.points = append(scanner.points, p)
+        }
+        scanners = append(scanners, scanner)
+    }
+    if len(scanners) == 0 {
+        return nil, fmt.Errorf("no scanners in input")
+    }
+    return scanners, nil
+}
+
+func init() {
+    runner.Register(19, part1, part2)
+    runner.RegisterTool(19, runner.Tool{
+        Name: "scanners",
+        Summary: "write out where every scanner and beacon is as JSON or CSV",
+        Run: scannersTool,
+    })
+}
+
+func solve(src reader.Source, workers int, logs *logger.Logger) (*Result, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return nil, err
+    }
+    scanners, err := scannersFromInput(lines)
+    if err != nil {
+        return nil, err
+    }
+    return Align(scanners, workers, logs)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    result, err := solve(src, runtime.NumCPU(), logs)
+    if err != nil {
+        return 0, err
+    }
+    return len(result.Beacons), nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    result, err := solve(src, runtime.NumCPU(), logs)
+    if err != nil {
+        return 0, err
+    }
+    return result.MaxDistance(), nil
+}
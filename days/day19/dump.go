@@ -0,0 +1,68 @@
+package day19
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "io"
+    "runtime"
+    "strconv"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// WriteCSV writes one row per scanner and then one per beacon. Scanner rows
+// carry their rotation matrix, row by row, in r11 to r33.
+func WriteCSV(w io.Writer, result *Result) error {
+    out := csv.NewWriter(w)
+    out.Write([]string{"kind", "label", "x", "y", "z", "r11", "r12", "r13", "r21", "r22", "r23", "r31", "r32", "r33"})
+    for _, s := range result.Scanners {
+        row := []string{"scanner", s.Label, strconv.Itoa(s.Position.x), strconv.Itoa(s.Position.y), strconv.Itoa(s.Position.z)}
+        for _, matrixRow := range s.Rotation {
+            for _, n := range matrixRow {
+                row = append(row, strconv.Itoa(n))
+            }
+        }
+        out.Write(row)
+    }
+    for _, b := range result.Beacons {
+        out.Write([]string{"beacon", "", strconv.Itoa(b.x), strconv.Itoa(b.y), strconv.Itoa(b.z), "", "", "", "", "", "", "", "", ""})
+    }
+    out.Flush()
+    return out.Error()
+}
+
+func scannersTool(args []string) error {
+    fs := flag.NewFlagSet("scanners", flag.ContinueOnError)
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    format := fs.String("format", "json", "json or csv")
+    output := fs.String("output", "-", "file to write to, - for stdout")
+    workers := fs.Int("workers", runtime.NumCPU(), "how many alignments to try at once")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+    if *format != "json" && *format != "csv" {
+        return fmt.Errorf("unknown format %q, want json or csv", *format)
+    }
+    result, err := solve(reader.SourceFor(19, *input), *workers, logger.Logs)
+    if err != nil {
+        return err
+    }
+    return runner.WriteOutput(*output, func(w io.Writer) error {
+        return writeResult(w, *format, result)
+    })
+}
+
+func writeResult(w io.Writer, format string, result *Result) error {
+    if format == "csv" {
+        return WriteCSV(w, result)
+    }
+    data, err := json.MarshalIndent(result, "", "  ")
+    if err != nil {
+        return err
+    }
+    _, err = fmt.Fprintln(w, string(data))
+    return err
+}
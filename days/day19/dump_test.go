@@ -0,0 +1,65 @@
+package day19
+
+import (
+    "bytes"
+    "encoding/csv"
+    "encoding/json"
+    "reflect"
+    "testing"
+)
+
+var small = &Result{
+    Scanners: []Placement{
+        {Label: "0", Rotation: Identity},
+        {Label: "1", Rotation: Rotation{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}}, Position: Point{68, -1246, -43}},
+    },
+    Beacons: []Point{{-618, -824, -621}, {459, -707, 401}},
+}
+
+func TestWriteJSON(t *testing.T) {
+    var out bytes.Buffer
+    if err := writeResult(&out, "json", small); err != nil {
+        t.Fatal(err)
+    }
+    var got struct {
+        Scanners []struct {
+            Label string
+            Rotation [3][3]int
+            Position [3]int
+        }
+        Beacons [][3]int
+    }
+    if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+        t.Fatalf("%v in\n%s", err, out.String())
+    }
+    if len(got.Scanners) != 2 || got.Scanners[1].Label != "1" || got.Scanners[1].Position != [3]int{68, -1246, -43} {
+        t.Errorf("scanners came out as %+v", got.Scanners)
+    }
+    if got.Scanners[0].Rotation != Identity || got.Scanners[1].Rotation != small.Scanners[1].Rotation {
+        t.Errorf("rotations came out as %v and %v", got.Scanners[0].Rotation, got.Scanners[1].Rotation)
+    }
+    if ! reflect.DeepEqual(got.Beacons, [][3]int{{-618, -824, -621}, {459, -707, 401}}) {
+        t.Errorf("beacons came out as %v", got.Beacons)
+    }
+}
+
+func TestWriteCSV(t *testing.T) {
+    var out bytes.Buffer
+    if err := writeResult(&out, "csv", small); err != nil {
+        t.Fatal(err)
+    }
+    rows, err := csv.NewReader(&out).ReadAll()
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := [][]string{
+        {"kind", "label", "x", "y", "z", "r11", "r12", "r13", "r21", "r22", "r23", "r31", "r32", "r33"},
+        {"scanner", "0", "0", "0", "0", "1", "0", "0", "0", "1", "0", "0", "0", "1"},
+        {"scanner", "1", "68", "-1246", "-43", "0", "-1", "0", "1", "0", "0", "0", "0", "1"},
+        {"beacon", "", "-618", "-824", "-621", "", "", "", "", "", "", "", "", ""},
+        {"beacon", "", "459", "-707", "401", "", "", "", "", "", "", "", "", ""},
+    }
+    if ! reflect.DeepEqual(rows, want) {
+        t.Errorf("got rows\n%v\nexpected\n%v", rows, want)
+    }
+}
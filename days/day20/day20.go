@@ -0,0 +1,92 @@
+package day20
+
+import (
+    "fmt"
+    grid "advent2021/adventgrid"
+    logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+type Board struct {
+    image *Image
+    algorithm *Algorithm // [ types furiously ] "Enhance."
+}
+
+func (b *Board) Print() {
+    fmt.Print(b.image)
+}
+
+func (b *Board) enhanceN(num int) {
+    for i := 0; i < num; i++ {
+        b.image = b.image.Enhance(b.algorithm)
+    }
+}
+
+func (b *Board) countLit() (int, error) {
+    if b.image.background {
+        return 0, fmt.Errorf("the background is lit, so infinitely many pixels are")
+    }
+    return b.image.Count(), nil
+}
+
+func boardFromInput(lines []string) (*Board, error) {
+    blocks := parser.Blocks(lines)
+    if len(blocks) != 2 || len(blocks[0].Lines) != 1 {
+        return nil, fmt.Errorf("expected an enhancement line, a blank line and an image")
+    }
+    enhance := blocks[0].Lines[0]
+    var algorithm Algorithm
+    if len(enhance) != len(algorithm) {
+        return nil, &parser.LineError{Line: 1, Err: fmt.Errorf("enhancement is %d long, expected %d", len(enhance), len(algorithm))}
+    }
+    for i := range enhance {
+        switch enhance[i] {
+        case '#':
+            algorithm[i] = true
+        case '.':
+        default:
+            return nil, &parser.LineError{Line: 1, Err: fmt.Errorf("column %d: expected # or ., got %q", i + 1, enhance[i])}
+        }
+    }
+    pixels, err := grid.Parse(blocks[1].Lines, func(char byte) (bool, error) {
+        if char != '#' && char != '.' {
+            return false, fmt.Errorf("expected # or ., got %q", char)
+        }
+        return char == '#', nil
+    })
+    if err != nil {
+        return nil, blocks[1].Err(err)
+    }
+    image := NewImage(pixels.Width(), pixels.Height())
+    pixels.Each(func(p grid.Point, lit bool) {
+        image.Set(p.X, p.Y, lit)
+    })
+    return &Board{image: image, algorithm: &algorithm}, nil
+}
+
+func init() {
+    runner.Register(20, part1, part2)
+}
+
+func solve(src reader.Source, rounds int) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    board, err := boardFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    board.enhanceN(rounds)
+    return board.countLit()
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    return solve(src, 2)
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    return solve(src, 50)
+}
@@ -0,0 +1,108 @@
+package day20
+
+import (
+    "math/bits"
+    "strings"
+)
+
+// Algorithm says whether the pixel at the middle of each possible 3x3 window
+// ends up lit, indexed by the window read as a 9 bit number: top row first,
+// left to right, lit pixels being 1.
+type Algorithm [1 << 9]bool
+
+// Image is a bitset of lit pixels, a row of 64 bit words at a time, plus
+// whether the rest of the infinite image around it is lit.
+type Image struct {
+    width, height int
+    stride int // words per row
+    pixels []uint64
+    background bool
+}
+
+func NewImage(width, height int) *Image {
+    stride := (width + 63) / 64
+    return &Image{width: width, height: height, stride: stride, pixels: make([]uint64, stride * height)}
+}
+
+func (im *Image) Width() int {
+    return im.width
+}
+
+func (im *Image) Height() int {
+    return im.height
+}
+
+// Lit reports whether a pixel is lit. Anything off the image is background.
+func (im *Image) Lit(x, y int) bool {
+    if x < 0 || y < 0 || x >= im.width || y >= im.height {
+        return im.background
+    }
+    return im.pixels[y * im.stride + x / 64] >> (x % 64) & 1 == 1
+}
+
+func (im *Image) Set(x, y int, lit bool) {
+    word, bit := y * im.stride + x / 64, uint64(1) << (x % 64)
+    if lit {
+        im.pixels[word] |= bit
+    } else {
+        im.pixels[word] &^= bit
+    }
+}
+
+// Count is how many pixels on the image are lit, not counting the background.
+func (im *Image) Count() int {
+    count := 0
+    for _, word := range im.pixels {
+        count += bits.OnesCount64(word)
+    }
+    return count
+}
+
+func (im *Image) String() string {
+    var out strings.Builder
+    for y := 0; y < im.height; y++ {
+        for x := 0; x < im.width; x++ {
+            if im.Lit(x, y) {
+                out.WriteByte('#')
+            } else {
+                out.WriteByte('.')
+            }
+        }
+        out.WriteByte('\n')
+    }
+    return out.String()
+}
+
+// bit is Lit as 0 or 1.
+func (im *Image) bit(x, y int) int {
+    if im.Lit(x, y) {
+        return 1
+    }
+    return 0
+}
+
+// Enhance returns the next image, one pixel bigger on every side since the
+// background can light up pixels next to the edge. Along each row the 3x3
+// window slides right a column at a time: shifting the index left drops each
+// row's leftmost pixel, and the new column goes in at the bottom of each row.
+func (im *Image) Enhance(algorithm *Algorithm) *Image {
+    next := NewImage(im.width + 2, im.height + 2)
+    outside := 0
+    if im.background {
+        outside = 0b111_111_111
+    }
+    for y := 0; y < next.height; y++ {
+        // next's (x, y) is centred on im's (x - 1, y - 1), so the window
+        // starts out over the background to the left of im
+        index := outside
+        for x := 0; x < next.width; x++ {
+            column := im.bit(x, y - 2) << 6 | im.bit(x, y - 1) << 3 | im.bit(x, y)
+            index = index << 1 & 0b110_110_110 | column
+            if algorithm[index] {
+                next.Set(x, y, true)
+            }
+        }
+    }
+    next.background = algorithm[outside]
+    return next
+}
@@ -0,0 +1,92 @@
+package day20
+
+import (
+    "math/rand"
+    "testing"
+)
+
+// flashing is an algorithm like the real inputs': an all dark window lights
+// up and an all lit one goes dark, so the infinite background flashes.
+func flashing(rng *rand.Rand) *Algorithm {
+    var algorithm Algorithm
+    for i := range algorithm {
+        algorithm[i] = rng.Intn(2) == 1
+    }
+    algorithm[0], algorithm[511] = true, false
+    return &algorithm
+}
+
+func randomImage(rng *rand.Rand, width, height int) *Image {
+    im := NewImage(width, height)
+    for y := 0; y < height; y++ {
+        for x := 0; x < width; x++ {
+            im.Set(x, y, rng.Intn(3) == 0)
+        }
+    }
+    return im
+}
+
+// slowEnhance is what Enhance does, a window at a time.
+func slowEnhance(im *Image, algorithm *Algorithm) *Image {
+    next := NewImage(im.width + 2, im.height + 2)
+    for y := 0; y < next.height; y++ {
+        for x := 0; x < next.width; x++ {
+            index := 0
+            for dy := -1; dy <= 1; dy++ {
+                for dx := -1; dx <= 1; dx++ {
+                    index = index << 1 | im.bit(x - 1 + dx, y - 1 + dy)
+                }
+            }
+            next.Set(x, y, algorithm[index])
+        }
+    }
+    next.background = algorithm[im.bit(-1, -1) * 511]
+    return next
+}
+
+func TestFlashingBackground(t *testing.T) {
+    rng := rand.New(rand.NewSource(20))
+    algorithm := flashing(rng)
+    // wider than a word, so windows cross from one to the next
+    board := &Board{image: randomImage(rng, 70, 5), algorithm: algorithm}
+    slow := board.image
+    for round := 1; round <= 6; round++ {
+        board.enhanceN(1)
+        slow = slowEnhance(slow, algorithm)
+        if lit := round % 2 == 1; board.image.background != lit {
+            t.Fatalf("round %d: background lit is %v, expected %v", round, board.image.background, lit)
+        }
+        if board.image.String() != slow.String() || board.image.background != slow.background {
+            t.Fatalf("round %d: got\n%s\nexpected\n%s", round, board.image, slow)
+        }
+        count, err := board.countLit()
+        if round % 2 == 1 {
+            if err == nil {
+                t.Errorf("round %d: the background is lit but countLit gave %d", round, count)
+            }
+            continue
+        }
+        if err != nil {
+            t.Errorf("round %d: %v", round, err)
+        } else if count != slow.Count() {
+            t.Errorf("round %d: counted %d lit, expected %d", round, count, slow.Count())
+        }
+    }
+}
+
+func TestSteadyBackground(t *testing.T) {
+    // with algorithm[0] dark, as in the example, the background never lights
+    rng := rand.New(rand.NewSource(21))
+    algorithm := flashing(rng)
+    algorithm[0] = false
+    board := &Board{image: randomImage(rng, 10, 10), algorithm: algorithm}
+    for round := 1; round <= 3; round++ {
+        board.enhanceN(1)
+        if board.image.background {
+            t.Fatalf("round %d: the background lit up", round)
+        }
+        if _, err := board.countLit(); err != nil {
+            t.Errorf("round %d: %v", round, err)
+        }
+    }
+}
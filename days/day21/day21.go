@@ -1,11 +1,12 @@
-package main
+package day21
 
 import (
     "fmt"
     "regexp"
-    "strconv"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 type Die interface {
@@ -120,18 +121,25 @@ func (g *Game) Loser() []*Player {
     return losers
 }
 
-func gameFromInput(lines []string, gameOver int, dice Die) *Game {
-    numPlayers := len(lines)
-    game := NewGame(numPlayers, gameOver, dice)
-    for _, line := range lines {
-        reg := regexp.MustCompile(`Player\s(\d+)\sstarting\sposition:\s(\d+)`)
-        result := reg.FindStringSubmatch(line)
-        playerNumString, positionString := result[1], result[2]
-        playerNum, _ := strconv.Atoi(playerNumString)
-        position, _ := strconv.Atoi(positionString)
-        game.players[playerNum - 1] = NewPlayer(playerNum, position) // 0-indexed
+var playerReg = regexp.MustCompile(`^Player\s(?P<number>\d+)\sstarting\sposition:\s(?P<position>\d+)$`)
+
+type start struct {
+    Number, Position int
+}
+
+func gameFromInput(lines []string, gameOver int, dice Die) (*Game, error) {
+    starts := make([]start, 0)
+    if err := parser.MatchLines(playerReg, lines, &starts); err != nil {
+        return nil, err
+    }
+    game := NewGame(len(starts), gameOver, dice)
+    for i, s := range starts {
+        if s.Number < 1 || s.Number > len(starts) || game.players[s.Number - 1] != nil {
+            return nil, &parser.LineError{Line: i + 1, Err: fmt.Errorf("unexpected player number %d", s.Number)}
+        }
+        game.players[s.Number - 1] = NewPlayer(s.Number, s.Position) // 0-indexed
     }
-    return game
+    return game, nil
 }
 
 // ffs, start everything over for part 2
@@ -174,35 +182,44 @@ func winners(gameOver int, turnPlayer, otherPlayer Player, cache Cache) (int, in
 }
 // back to our regularly scheduled programming
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
+func init() {
+    runner.Register(21, part1, part2)
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
-    game := gameFromInput(lines, 1000, NewD100())
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    game, err := gameFromInput(lines, 1000, NewD100())
+    if err != nil {
+        return 0, err
+    }
     for ! game.Over() {
         game.Round()
     }
     rolls :=  game.dice.Rolls()
     loserScore := game.Loser()[0].score
-    logger.Logs.Infof("Game over with losing score %d, die rolls %d", loserScore, rolls)
-    return rolls * loserScore
+    logs.Infof("Game over with losing score %d, die rolls %d", loserScore, rolls)
+    return rolls * loserScore, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    game := gameFromInput(lines, 1000, NewD100())
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    game, err := gameFromInput(lines, 1000, NewD100())
+    if err != nil {
+        return 0, err
+    }
     player1 := game.players[0]
     player2 := game.players[1]
     cache := make(Cache)
     player1wins, player2wins := winners(21, *player1, *player2, cache)
     if player1wins > player2wins {
-        return player1wins
+        return player1wins, nil
     } else {
-        return player2wins
+        return player2wins, nil
     }
 }
@@ -1,11 +1,12 @@
-package main
+package day22
 
 import (
     "fmt"
     "regexp"
-    "strconv"
     logger "advent2021/adventlogger"
+    parser "advent2021/adventparser"
     reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
 )
 
 type Volume struct {
@@ -74,45 +75,43 @@ func (v Volume) ShearOff(ov Volume, instruct string) Cubes {
 
 type Cubes map[Volume]string
 
-func cubesFromInput(lines []string) ([]Volume, []string) {
+var stepReg = regexp.MustCompile(`^(?P<instruct>on|off)\sx=(?P<xMin>-?\d+)\.\.(?P<xMax>-?\d+),y=(?P<yMin>-?\d+)\.\.(?P<yMax>-?\d+),z=(?P<zMin>-?\d+)\.\.(?P<zMax>-?\d+)$`)
+
+type step struct {
+    Instruct string
+    XMin, XMax, YMin, YMax, ZMin, ZMax int
+}
+
+func cubesFromInput(lines []string) ([]Volume, []string, error) {
+    steps := make([]step, 0)
+    if err := parser.MatchLines(stepReg, lines, &steps); err != nil {
+        return nil, nil, err
+    }
     cubes := make([]Volume, 0)
     instructs := make([]string, 0)
-    for _, line := range lines {
-        reg := regexp.MustCompile(`(\w+)\sx=([0-9-]+)..([0-9-]+),y=([0-9-]+)..([0-9-]+),z=([0-9-]+)..([0-9-]+)`)
-        if result := reg.FindStringSubmatch(line); result != nil {
-            instruction := result[1]
-            xMinStr := result[2]
-            xMin, _ := strconv.Atoi(xMinStr)
-            xMaxStr := result[3]
-            xMax, _ := strconv.Atoi(xMaxStr)
-            yMinStr := result[4]
-            yMin, _ := strconv.Atoi(yMinStr)
-            yMaxStr := result[5]
-            yMax, _ := strconv.Atoi(yMaxStr)
-            zMinStr := result[6]
-            zMin, _ := strconv.Atoi(zMinStr)
-            zMaxStr := result[7]
-            zMax, _ := strconv.Atoi(zMaxStr)
-            cube := Volume{xMin, xMax, yMin, yMax, zMin, zMax}
-            cubes = append(cubes, cube)
-            instructs = append(instructs, instruction)
-        }
+    for _, s := range steps {
+        cube := Volume{s.XMin, s.XMax, s.YMin, s.YMax, s.ZMin, s.ZMax}
+        cubes = append(cubes, cube)
+        instructs = append(instructs, s.Instruct)
     }
-    return cubes, instructs
+    return cubes, instructs, nil
 }
 
-func main() {
-    result := part1()
-    logger.Logs.Infof("Part one result: %d", result)
-    result = part2()
-    logger.Logs.Infof("Part two result: %d", result)
+func init() {
+    runner.Register(22, part1, part2)
 }
 
-func part1() int {
-    lines := reader.LinesFromFile("input.txt")
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
     initialMin, initialMax := -50, 50
     initial := Volume{initialMin, initialMax, initialMin, initialMax, initialMin, initialMax}
-    cubes, instructs := cubesFromInput(lines)
+    cubes, instructs, err := cubesFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
     vList := make(Cubes)
     for i, cube := range cubes {
         instruct := instructs[i]
@@ -156,12 +155,18 @@ func part1() int {
             }
         }
     }
-    return sum
+    return sum, nil
 }
 
-func part2() int {
-    lines := reader.LinesFromFile("input.txt")
-    cubes, instructs := cubesFromInput(lines)
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    cubes, instructs, err := cubesFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
     vList := make(Cubes)
     for i, cube := range cubes {
         instruct := instructs[i]
@@ -201,6 +206,6 @@ func part2() int {
             sum += cube.Size()
         }
     }
-    return sum
+    return sum, nil
 }
 
@@ -0,0 +1,330 @@
+package day23
+
+import (
+    "fmt"
+    "strings"
+    graph "advent2021/adventgraph"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+const empty = '.'
+
+// Burrow is the fixed layout: a hallway and a row of rooms hanging off it,
+// all the same depth. Room i belongs to amphipod 'A'+i, and amphipod 'A'+i
+// costs 10^i energy per step.
+type Burrow struct {
+    hallwayLen int
+    roomsX []int // hallway position above each room, left to right
+    depth int
+    isDoor map[int]bool
+}
+
+// State is a canonical encoding of where every amphipod is: the hallway
+// left to right, then each room from the top down. Equal positions always
+// give equal States, which is what lets the search recognise a board it has
+// already reached another way.
+type State string
+
+func (b *Burrow) roomCell(room, slot int) int {
+    return b.hallwayLen + room * b.depth + slot
+}
+
+func (b *Burrow) energy(pod byte) int {
+    energy := 1
+    for i := byte('A'); i < pod; i++ {
+        energy *= 10
+    }
+    return energy
+}
+
+func (b *Burrow) roomOf(pod byte) int {
+    return int(pod - 'A')
+}
+
+// Goal is the state with everyone home and the hallway empty.
+func (b *Burrow) Goal() State {
+    cells := []byte(strings.Repeat(string(empty), b.hallwayLen))
+    for room := range b.roomsX {
+        for slot := 0; slot < b.depth; slot++ {
+            cells = append(cells, byte('A' + room))
+        }
+    }
+    return State(cells)
+}
+
+// clear reports whether the hallway is empty between from and to, not
+// counting from itself.
+func (b *Burrow) clear(s State, from, to int) bool {
+    step := 1
+    if to < from {
+        step = -1
+    }
+    for x := from + step; x != to + step; x += step {
+        if s[x] != empty {
+            return false
+        }
+    }
+    return true
+}
+
+// settled reports whether room only holds amphipods that belong there (it
+// may also be partly or entirely empty).
+func (b *Burrow) settled(s State, room int) bool {
+    for slot := 0; slot < b.depth; slot++ {
+        pod := s[b.roomCell(room, slot)]
+        if pod != empty && b.roomOf(pod) != room {
+            return false
+        }
+    }
+    return true
+}
+
+// deepestFree returns the lowest empty slot in the room.
+func (b *Burrow) deepestFree(s State, room int) int {
+    slot := b.depth - 1
+    for slot >= 0 && s[b.roomCell(room, slot)] != empty {
+        slot--
+    }
+    return slot
+}
+
+func abs(n int) int {
+    if n < 0 {
+        return -n
+    }
+    return n
+}
+
+func move(s State, from, to int) State {
+    cells := []byte(s)
+    cells[to], cells[from] = cells[from], empty
+    return State(cells)
+}
+
+// Neighbours makes the burrow a graph over states. An amphipod either leaves
+// its room for a hallway spot that isn't a doorway, or goes home: into the
+// deepest free slot of its own room, once that room holds nobody else.
+// Going home straight from another room is the same as stopping in the
+// hallway on the way, just without the stop.
+func (b *Burrow) Neighbours(s State) []graph.Edge[State] {
+    edges := make([]graph.Edge[State], 0)
+    home := func(from, x int, pod byte, steps int) {
+        room := b.roomOf(pod)
+        if ! b.settled(s, room) || ! b.clear(s, x, b.roomsX[room]) {
+            return
+        }
+        slot := b.deepestFree(s, room)
+        steps += abs(x - b.roomsX[room]) + slot + 1
+        edges = append(edges, graph.Edge[State]{To: move(s, from, b.roomCell(room, slot)), Cost: steps * b.energy(pod)})
+    }
+    for x := 0; x < b.hallwayLen; x++ {
+        if pod := s[x]; pod != empty {
+            home(x, x, pod, 0)
+        }
+    }
+    for room, roomX := range b.roomsX {
+        if b.settled(s, room) {
+            // nobody here needs to leave
+            continue
+        }
+        slot := 0
+        for s[b.roomCell(room, slot)] == empty {
+            slot++
+        }
+        from := b.roomCell(room, slot)
+        pod := s[from]
+        if b.roomOf(pod) != room {
+            home(from, roomX, pod, slot + 1)
+        }
+        for x := 0; x < b.hallwayLen; x++ {
+            if b.isDoor[x] || ! b.clear(s, roomX, x) {
+                continue
+            }
+            steps := slot + 1 + abs(x - roomX)
+            edges = append(edges, graph.Edge[State]{To: move(s, from, x), Cost: steps * b.energy(pod)})
+        }
+    }
+    return edges
+}
+
+// Sprint draws a state the way the puzzle does.
+func (b *Burrow) Sprint(s State) string {
+    var out strings.Builder
+    out.WriteString(strings.Repeat("#", b.hallwayLen + 2) + "\n")
+    out.WriteString("#" + string(s[:b.hallwayLen]) + "#\n")
+    first, last := b.roomsX[0], b.roomsX[len(b.roomsX) - 1]
+    for slot := 0; slot < b.depth; slot++ {
+        // the top row of rooms is walled all the way across, the rest just
+        // around the rooms
+        row := []byte(strings.Repeat("#", b.hallwayLen + 2))
+        if slot > 0 {
+            row = []byte(strings.Repeat(" ", last + 3))
+            for x := first; x <= last + 2; x++ {
+                row[x] = '#'
+            }
+        }
+        for room, roomX := range b.roomsX {
+            row[roomX + 1] = s[b.roomCell(room, slot)]
+        }
+        out.WriteString(string(row) + "\n")
+    }
+    out.WriteString(strings.Repeat(" ", first) + strings.Repeat("#", last - first + 3) + "\n")
+    return out.String()
+}
+
+// burrowFromInput reads the diagram: a wall, the hallway, then one line per
+// row of rooms, then a wall. Any number of rooms and rows will do as long as
+// every room's amphipods are all there.
+func burrowFromInput(lines []string) (*Burrow, State, error) {
+    for len(lines) > 0 && strings.TrimSpace(lines[len(lines) - 1]) == "" {
+        lines = lines[:len(lines) - 1]
+    }
+    if len(lines) < 4 {
+        return nil, "", fmt.Errorf("expected a wall, the hallway, rows of rooms and a wall, got %d lines", len(lines))
+    }
+    hallway := strings.TrimSpace(lines[1])
+    if len(hallway) < 3 || hallway[0] != '#' || hallway[len(hallway) - 1] != '#' {
+        return nil, "", fmt.Errorf("line 2: %q doesn't look like a hallway", lines[1])
+    }
+    burrow := &Burrow{hallwayLen: len(hallway) - 2, isDoor: make(map[int]bool)}
+    cells := []byte(hallway[1:len(hallway) - 1])
+    rows := lines[2:len(lines) - 1]
+    burrow.depth = len(rows)
+    rooms := make([][]byte, 0)
+    for i, line := range rows {
+        pods := make([]byte, 0)
+        xs := make([]int, 0)
+        for col := 0; col < len(line); col++ {
+            if line[col] == '#' || line[col] == ' ' {
+                continue
+            }
+            pods = append(pods, line[col])
+            xs = append(xs, col - 1)
+        }
+        if i == 0 {
+            burrow.roomsX = xs
+            for _, x := range xs {
+                if x < 0 || x >= burrow.hallwayLen {
+                    return nil, "", fmt.Errorf("line 3: room at column %d is off the hallway", x + 2)
+                }
+                burrow.isDoor[x] = true
+                rooms = append(rooms, make([]byte, 0, burrow.depth))
+            }
+        }
+        if fmt.Sprint(xs) != fmt.Sprint(burrow.roomsX) {
+            return nil, "", fmt.Errorf("line %d: rooms don't line up with the ones above", i + 3)
+        }
+        for room, pod := range pods {
+            rooms[room] = append(rooms[room], pod)
+        }
+    }
+    if len(burrow.roomsX) == 0 {
+        return nil, "", fmt.Errorf("line 3: no rooms")
+    }
+    for _, room := range rooms {
+        cells = append(cells, room...)
+    }
+    start := State(cells)
+    // the start has to hold exactly the amphipods the goal does
+    count := make(map[byte]int)
+    for _, pod := range []byte(start) {
+        if pod != empty && (pod < 'A' || int(pod - 'A') >= len(burrow.roomsX)) {
+            return nil, "", fmt.Errorf("found %q, expected amphipods A to %c", pod, 'A' + len(burrow.roomsX) - 1)
+        }
+        count[pod]++
+    }
+    for room := range burrow.roomsX {
+        pod := byte('A' + room)
+        if count[pod] != burrow.depth {
+            return nil, "", fmt.Errorf("rooms %d deep need %d of %c, found %d", burrow.depth, burrow.depth, pod, count[pod])
+        }
+    }
+    return burrow, start, nil
+}
+
+// folded are the rows of rooms the part 2 instructions reveal. They're only
+// given for the puzzle's 4 rooms.
+var folded = []string{"DCBA", "DBAC"}
+
+// unfold adds the folded rows under the first row of rooms, drawn like the
+// row below it.
+func unfold(lines []string) ([]string, error) {
+    if len(lines) < 4 {
+        return nil, fmt.Errorf("expected a wall, the hallway, rows of rooms and a wall, got %d lines", len(lines))
+    }
+    template := []byte(lines[3])
+    rooms := make([]int, 0)
+    for col, char := range template {
+        if char != '#' && char != ' ' {
+            rooms = append(rooms, col)
+        }
+    }
+    if len(rooms) != len(folded[0]) {
+        return nil, fmt.Errorf("line 4: the part 2 rows are only known for %d rooms, found %d", len(folded[0]), len(rooms))
+    }
+    unfolded := append([]string{}, lines[:3]...)
+    for _, pods := range folded {
+        row := append([]byte{}, template...)
+        for i, col := range rooms {
+            row[col] = pods[i]
+        }
+        unfolded = append(unfolded, string(row))
+    }
+    return append(unfolded, lines[3:]...), nil
+}
+
+// solve finds the least energy that gets everyone home.
+func solve(burrow *Burrow, start State, logs *logger.Logger) (graph.Path[State], error) {
+    goal := burrow.Goal()
+    isGoal := func(s State) bool { return s == goal }
+    path, stats, err := graph.Dijkstra[State](burrow, start, isGoal)
+    if err != nil {
+        return path, err
+    }
+    logs.WithFields(logger.Fields{
+        "expanded": stats.Expanded,
+        "pushed": stats.Pushed,
+        "moves": len(path.Nodes) - 1,
+    }).Infof("Organised the amphipods for %d energy", path.Cost)
+    return path, nil
+}
+
+func run(src reader.Source, unfolded bool, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    if unfolded {
+        if lines, err = unfold(lines); err != nil {
+            return 0, err
+        }
+    }
+    burrow, start, err := burrowFromInput(lines)
+    if err != nil {
+        return 0, err
+    }
+    path, err := solve(burrow, start, logs)
+    if err != nil {
+        return 0, err
+    }
+    return path.Cost, nil
+}
+
+func init() {
+    runner.Register(23, part1, part2)
+    runner.RegisterTool(23, runner.Tool{
+        Name: "replay",
+        Summary: "show the cheapest solution move by move, or export the moves as JSON",
+        Run: replayTool,
+    })
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    return run(src, false, logs)
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    return run(src, true, logs)
+}
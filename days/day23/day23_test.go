@@ -0,0 +1,58 @@
+package day23
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestUnfold(t *testing.T) {
+    got, err := unfold(example)
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := []string{
+        "#############",
+        "#...........#",
+        "###B#C#B#D###",
+        "  #D#C#B#A#",
+        "  #D#B#A#C#",
+        "  #A#D#C#A#",
+        "  #########",
+    }
+    if ! reflect.DeepEqual(got, want) {
+        t.Errorf("got\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+    }
+    if _, _, err := burrowFromInput(got); err != nil {
+        t.Errorf("the unfolded example doesn't parse: %v", err)
+    }
+}
+
+func TestUnfoldOtherRooms(t *testing.T) {
+    layouts := [][]string{
+        {
+            "###########",
+            "#.........#",
+            "###B#A#C###",
+            "  #A#B#C#",
+            "  #######",
+        },
+        {
+            "###############",
+            "#.............#",
+            "###B#C#B#D#E###",
+            "  #A#D#C#A#E#",
+            "  ###########",
+        },
+    }
+    for _, lines := range layouts {
+        // they're fine as they are
+        if _, _, err := burrowFromInput(lines); err != nil {
+            t.Fatalf("%v\n%s", err, strings.Join(lines, "\n"))
+        }
+        _, err := unfold(lines)
+        if err == nil || ! strings.Contains(err.Error(), "only known for 4 rooms") {
+            t.Errorf("unfolding %d rooms: expected an error about the room count, got %v", len(lines[3]) / 2 - 1, err)
+        }
+    }
+}
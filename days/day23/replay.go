@@ -0,0 +1,141 @@
+package day23
+
+import (
+    "encoding/json"
+    "flag"
+    "fmt"
+    "io"
+    "os"
+    graph "advent2021/adventgraph"
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+// Position is a spot in the diagram: X along the hallway, Y 0 for the
+// hallway itself and 1 and down for the slots of a room.
+type Position struct {
+    X int `json:"x"`
+    Y int `json:"y"`
+}
+
+func (p Position) String() string {
+    return fmt.Sprintf("%d,%d", p.X, p.Y)
+}
+
+// Move is one amphipod moving, and what it cost.
+type Move struct {
+    Pod string `json:"pod"`
+    From Position `json:"from"`
+    To Position `json:"to"`
+    Steps int `json:"steps"`
+    Energy int `json:"energy"`
+    Total int `json:"total"` // energy spent so far, this move included
+}
+
+func (m Move) String() string {
+    return fmt.Sprintf("%s from %v to %v: %d steps, %d energy (%d total)", m.Pod, m.From, m.To, m.Steps, m.Energy, m.Total)
+}
+
+func (b *Burrow) position(cell int) Position {
+    if cell < b.hallwayLen {
+        return Position{X: cell}
+    }
+    room, slot := (cell - b.hallwayLen) / b.depth, (cell - b.hallwayLen) % b.depth
+    return Position{X: b.roomsX[room], Y: slot + 1}
+}
+
+// Moves works out which amphipod moved between each pair of states on a
+// solved path, and checks the energy adds up to what the path cost.
+func (b *Burrow) Moves(path graph.Path[State]) ([]Move, error) {
+    states := path.Nodes
+    moves := make([]Move, 0, len(states))
+    total := 0
+    for i := 1; i < len(states); i++ {
+        prev, next := states[i - 1], states[i]
+        from, to, changed := -1, -1, 0
+        for cell := range prev {
+            if prev[cell] == next[cell] {
+                continue
+            }
+            changed++
+            switch {
+            case next[cell] == empty:
+                from = cell
+            case prev[cell] == empty:
+                to = cell
+            }
+        }
+        // one cell emptied and one filled, by the same amphipod
+        if changed != 2 || from < 0 || to < 0 || prev[from] != next[to] {
+            return nil, fmt.Errorf("states %d and %d aren't a single move apart", i - 1, i)
+        }
+        pod := prev[from]
+        start, end := b.position(from), b.position(to)
+        // up out of wherever it is, along the hallway, down into wherever it's going
+        steps := start.Y + abs(start.X - end.X) + end.Y
+        energy := steps * b.energy(pod)
+        total += energy
+        moves = append(moves, Move{string(pod), start, end, steps, energy, total})
+    }
+    if total != path.Cost {
+        return nil, fmt.Errorf("the moves add up to %d energy, but the path cost %d", total, path.Cost)
+    }
+    return moves, nil
+}
+
+// Replay prints every board on the way to the solution, with the move that
+// got there.
+func (b *Burrow) Replay(w io.Writer, path graph.Path[State]) error {
+    moves, err := b.Moves(path)
+    if err != nil {
+        return err
+    }
+    fmt.Fprintf(w, "Start\n%s\n", b.Sprint(path.Nodes[0]))
+    for i, move := range moves {
+        fmt.Fprintf(w, "Move %d: %v\n%s\n", i + 1, move, b.Sprint(path.Nodes[i + 1]))
+    }
+    return nil
+}
+
+func replayTool(args []string) error {
+    fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+    input := fs.String("input", "input.txt", "input file name, path, or - for stdin")
+    unfolded := fs.Bool("unfold", false, "add the part 2 rows of rooms first")
+    jsonFile := fs.String("json", "", "write the moves as JSON to this file (- for stdout) instead of replaying them")
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+    lines, err := reader.ReadLines(reader.SourceFor(23, *input))
+    if err != nil {
+        return err
+    }
+    if *unfolded {
+        if lines, err = unfold(lines); err != nil {
+            return err
+        }
+    }
+    burrow, start, err := burrowFromInput(lines)
+    if err != nil {
+        return err
+    }
+    path, err := solve(burrow, start, logger.Logs)
+    if err != nil {
+        return err
+    }
+    if *jsonFile == "" {
+        return burrow.Replay(os.Stdout, path)
+    }
+    moves, err := burrow.Moves(path)
+    if err != nil {
+        return err
+    }
+    data, err := json.MarshalIndent(moves, "", "  ")
+    if err != nil {
+        return err
+    }
+    return runner.WriteOutput(*jsonFile, func(w io.Writer) error {
+        _, err := fmt.Fprintln(w, string(data))
+        return err
+    })
+}
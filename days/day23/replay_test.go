@@ -0,0 +1,113 @@
+package day23
+
+import (
+    "io"
+    "testing"
+    graph "advent2021/adventgraph"
+    logger "advent2021/adventlogger"
+    "github.com/sirupsen/logrus"
+)
+
+var example = []string{
+    "#############",
+    "#...........#",
+    "###B#C#B#D###",
+    "  #A#D#C#A#",
+    "  #########",
+}
+
+func exampleBurrow(t *testing.T) (*Burrow, State) {
+    t.Helper()
+    burrow, start, err := burrowFromInput(example)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return burrow, start
+}
+
+// cell is the inverse of position.
+func (b *Burrow) cell(p Position) int {
+    if p.Y == 0 {
+        return p.X
+    }
+    for room, x := range b.roomsX {
+        if x == p.X {
+            return b.roomCell(room, p.Y - 1)
+        }
+    }
+    panic("no room at " + p.String())
+}
+
+// the puzzle's own way of organising the example
+var exampleMoves = []Move{
+    {"B", Position{6, 1}, Position{3, 0}, 4, 40, 40},
+    {"C", Position{4, 1}, Position{6, 1}, 4, 400, 440},
+    {"D", Position{4, 2}, Position{5, 0}, 3, 3000, 3440},
+    {"B", Position{3, 0}, Position{4, 2}, 3, 30, 3470},
+    {"B", Position{2, 1}, Position{4, 1}, 4, 40, 3510},
+    {"D", Position{8, 1}, Position{7, 0}, 2, 2000, 5510},
+    {"A", Position{8, 2}, Position{9, 0}, 3, 3, 5513},
+    {"D", Position{7, 0}, Position{8, 2}, 3, 3000, 8513},
+    {"D", Position{5, 0}, Position{8, 1}, 4, 4000, 12513},
+    {"A", Position{9, 0}, Position{2, 1}, 8, 8, 12521},
+}
+
+func TestMoves(t *testing.T) {
+    burrow, start := exampleBurrow(t)
+    states := []State{start}
+    for _, m := range exampleMoves {
+        states = append(states, move(states[len(states) - 1], burrow.cell(m.From), burrow.cell(m.To)))
+    }
+    if states[len(states) - 1] != burrow.Goal() {
+        t.Fatalf("the moves don't get everyone home:\n%s", burrow.Sprint(states[len(states) - 1]))
+    }
+    moves, err := burrow.Moves(graph.Path[State]{Nodes: states, Cost: 12521})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(moves) != len(exampleMoves) {
+        t.Fatalf("got %d moves, expected %d", len(moves), len(exampleMoves))
+    }
+    for i := range moves {
+        if moves[i] != exampleMoves[i] {
+            t.Errorf("move %d: got %v, expected %v", i + 1, moves[i], exampleMoves[i])
+        }
+    }
+    if _, err := burrow.Moves(graph.Path[State]{Nodes: states, Cost: 12520}); err == nil {
+        t.Errorf("moves adding up to 12521 passed for a path costing 12520")
+    }
+}
+
+func TestMovesNotOneMove(t *testing.T) {
+    burrow, start := exampleBurrow(t)
+    out := move(start, burrow.cell(Position{6, 1}), burrow.cell(Position{3, 0}))
+    // one move, plus another amphipod swapped for a different one
+    swapped := []byte(out)
+    swapped[burrow.cell(Position{2, 2})] = 'B'
+    // two amphipods moving at once
+    both := move(out, burrow.cell(Position{8, 1}), burrow.cell(Position{7, 0}))
+    // an amphipod that turns into another on the way
+    changed := []byte(out)
+    changed[burrow.cell(Position{3, 0})] = 'A'
+    for _, next := range []State{State(swapped), both, State(changed), start} {
+        if moves, err := burrow.Moves(graph.Path[State]{Nodes: []State{start, next}}); err == nil {
+            t.Errorf("got %v from\n%s\nto\n%s", moves, burrow.Sprint(start), burrow.Sprint(next))
+        }
+    }
+}
+
+func TestSolveMoves(t *testing.T) {
+    burrow, start := exampleBurrow(t)
+    quiet := logger.GetLoggers(io.Discard, io.Discard, io.Discard, io.Discard, &logrus.TextFormatter{}, logrus.InfoLevel)
+    path, err := solve(burrow, start, quiet)
+    if err != nil {
+        t.Fatal(err)
+    }
+    moves, err := burrow.Moves(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if path.Cost != 12521 || moves[len(moves) - 1].Total != 12521 {
+        t.Errorf("solved for %d energy with moves adding up to %d, expected 12521", path.Cost, moves[len(moves) - 1].Total)
+    }
+}
@@ -1,6 +1,6 @@
 module advent2021
 
-go 1.17
+go 1.18
 
 require github.com/sirupsen/logrus v1.8.1
 
@@ -3,7 +3,33 @@ if [ -z "${1}" ]; then
   echo "Usage: mkday.sh [0-9]+"
   exit 1
 fi
-mkdir cmd/day${1}
-touch cmd/day${1}/day${1}.go
-touch cmd/day${1}/test.txt
-touch cmd/day${1}/input.txt
+mkdir days/day${1}
+cat > days/day${1}/day${1}.go <<GO
+package day${1}
+
+import (
+    logger "advent2021/adventlogger"
+    reader "advent2021/adventreader"
+    runner "advent2021/adventrunner"
+)
+
+func init() {
+    runner.Register($((10#${1})), part1, part2)
+}
+
+func part1(src reader.Source, logs *logger.Logger) (int, error) {
+    lines, err := reader.ReadLines(src)
+    if err != nil {
+        return 0, err
+    }
+    return len(lines), nil
+}
+
+func part2(src reader.Source, logs *logger.Logger) (int, error) {
+    return 0, runner.ErrUnsolved
+}
+GO
+touch days/day${1}/test.txt
+touch days/day${1}/input.txt
+# register the new day with the advent binary
+sed -i "s|^)$|    _ \"advent2021/days/day${1}\"\n)|" cmd/advent/days.go